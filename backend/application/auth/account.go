@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/mail"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+const (
+	// minPasswordLength is the shortest password accepted at registration or reset
+	minPasswordLength = 8
+
+	// maxPasswordBytes is the longest password bcrypt can hash without truncation
+	maxPasswordBytes = 72
+)
+
+// AccountUseCase represents the account use cases of the self-hosted auth
+// provider: registration, password login and password reset
+type AccountUseCase struct {
+	credentials auth.CredentialRepository
+	userRepo    user.Repository
+	hasher      auth.PasswordHasher
+	issuer      auth.TokenIssuer
+	mailer      mail.Sender
+	resetURL    string
+
+	// requireApproval makes registered accounts pending until staff
+	// reinstate them
+	requireApproval bool
+}
+
+// NewAccountUseCase creates a new account use case. resetURL is the page the
+// password reset email links to; the reset token is appended to it.
+func NewAccountUseCase(
+	credentials auth.CredentialRepository,
+	userRepo user.Repository,
+	hasher auth.PasswordHasher,
+	issuer auth.TokenIssuer,
+	mailer mail.Sender,
+	resetURL string,
+	requireApproval bool,
+) *AccountUseCase {
+	return &AccountUseCase{
+		credentials:     credentials,
+		userRepo:        userRepo,
+		hasher:          hasher,
+		issuer:          issuer,
+		mailer:          mailer,
+		resetURL:        resetURL,
+		requireApproval: requireApproval,
+	}
+}
+
+// Register creates a user with a password credential and signs them in
+func (uc *AccountUseCase) Register(ctx context.Context, email, password string) (*user.User, *auth.IssuedToken, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkPassword(password); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := uc.credentials.FindByEmail(ctx, email); err == nil {
+		return nil, nil, auth.ErrEmailTaken
+	} else if !errors.Is(err, auth.ErrCredentialNotFound) {
+		return nil, nil, err
+	}
+
+	if _, err := uc.userRepo.FindByEmail(ctx, email); err == nil {
+		return nil, nil, auth.ErrEmailTaken
+	} else if !errors.Is(err, user.ErrUserNotFound) {
+		return nil, nil, err
+	}
+
+	hash, err := uc.hasher.Hash(password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newUser := user.NewUser(uuid.NewString(), email)
+	if uc.requireApproval {
+		newUser.Status = user.StatusPending
+	}
+
+	// The credential goes first: its unique login email reserves the address,
+	// and a credential without a user is removed below, whereas a user
+	// without a credential could never sign in or be registered again
+	if err := uc.credentials.Create(ctx, auth.NewCredential(newUser.ID, email, hash)); err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.userRepo.Create(ctx, newUser); err != nil {
+		if deleteErr := uc.credentials.Delete(ctx, newUser.ID); deleteErr != nil {
+			logging.FromContext(ctx).Error("failed to remove the credential of an unregistered user",
+				"user_id", newUser.ID, "error", deleteErr)
+		}
+		return nil, nil, err
+	}
+
+	token, err := uc.issuer.IssueAccessToken(ctx, newUser.ID, email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logging.FromContext(ctx).Info("account registered", "user_id", newUser.ID, "status", newUser.Status)
+	return newUser, token, nil
+}
+
+// Login checks an email/password pair and issues an access token
+func (uc *AccountUseCase) Login(ctx context.Context, email, password string) (*auth.IssuedToken, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	credential, err := uc.credentials.FindByEmail(ctx, email)
+	if errors.Is(err, auth.ErrCredentialNotFound) {
+		// Spend the same time as a password check so response times do not
+		// reveal which emails are registered
+		uc.hasher.Hash(password)
+		return nil, auth.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.hasher.Compare(credential.PasswordHash, password); err != nil {
+		return nil, err
+	}
+
+	return uc.issuer.IssueAccessToken(ctx, credential.UserID, credential.Email)
+}
+
+// RequestPasswordReset emails a password reset link to the address if it
+// belongs to an account. It reports success either way so that callers
+// cannot probe which emails are registered.
+func (uc *AccountUseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	credential, err := uc.credentials.FindByEmail(ctx, email)
+	if errors.Is(err, auth.ErrCredentialNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := uc.issuer.IssueResetToken(ctx, credential.UserID, passwordFingerprint(credential.PasswordHash))
+	if err != nil {
+		return err
+	}
+
+	return uc.mailer.Send(ctx, mail.Message{
+		To:      credential.Email,
+		Subject: "Reset your Tool Rental Club password",
+		Body: fmt.Sprintf(
+			"Someone asked to reset the password of your Tool Rental Club account.\n\n"+
+				"Open the link below within 30 minutes to choose a new password:\n\n%s%s\n\n"+
+				"If this wasn't you, you can ignore this email.",
+			uc.resetURL, token.Value,
+		),
+	})
+}
+
+// ResetPassword sets a new password using a token from RequestPasswordReset.
+// Each token works only once: changing the password invalidates it.
+func (uc *AccountUseCase) ResetPassword(ctx context.Context, tokenValue, newPassword string) error {
+	if err := checkPassword(newPassword); err != nil {
+		return err
+	}
+
+	userID, fingerprint, err := uc.issuer.VerifyResetToken(ctx, tokenValue)
+	if err != nil {
+		return auth.ErrInvalidResetToken
+	}
+
+	credential, err := uc.credentials.FindByUserID(ctx, userID)
+	if errors.Is(err, auth.ErrCredentialNotFound) {
+		return auth.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if passwordFingerprint(credential.PasswordHash) != fingerprint {
+		return auth.ErrInvalidResetToken
+	}
+
+	hash, err := uc.hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	credential.PasswordHash = hash
+	credential.UpdatedAt = time.Now()
+
+	if err := uc.credentials.Update(ctx, credential); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("password reset", "user_id", userID)
+	return nil
+}
+
+// normalizeEmail validates an email address and lower-cases it
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	addr, err := netmail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", auth.ErrInvalidEmail.WithField("email", "must be a valid email address")
+	}
+
+	return email, nil
+}
+
+// checkPassword enforces the password policy
+func checkPassword(password string) error {
+	if len([]rune(password)) < minPasswordLength {
+		return auth.ErrWeakPassword.WithField("password", fmt.Sprintf("must be at least %d characters", minPasswordLength))
+	}
+	if len(password) > maxPasswordBytes {
+		return auth.ErrWeakPassword.WithField("password", fmt.Sprintf("must be at most %d bytes", maxPasswordBytes))
+	}
+	return nil
+}
+
+// passwordFingerprint derives a short, non-reversible identifier of the
+// current password hash, which changes whenever the password changes
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/mail"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/infrastructure/localauth"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/memory"
+)
+
+const resetURL = "https://club.example.com/reset?token="
+
+// outbox is a mail.Sender that keeps the messages it is asked to send
+type outbox []mail.Message
+
+func (o *outbox) Send(ctx context.Context, msg mail.Message) error {
+	*o = append(*o, msg)
+	return nil
+}
+
+// resetToken returns the token linked from the last message in the outbox
+func (o *outbox) resetToken(t *testing.T) string {
+	t.Helper()
+
+	if len(*o) == 0 {
+		t.Fatal("no email was sent")
+	}
+	body := (*o)[len(*o)-1].Body
+	_, rest, found := strings.Cut(body, resetURL)
+	if !found {
+		t.Fatalf("email does not link to the reset page:\n%s", body)
+	}
+	token, _, _ := strings.Cut(rest, "\n")
+	return token
+}
+
+// errDown is returned by the failing repositories
+var errDown = errors.New("database is down")
+
+// failingUsers is a user repository whose Create fails while fail is set
+type failingUsers struct {
+	*memory.UserRepository
+	fail bool
+}
+
+func (r *failingUsers) Create(ctx context.Context, u *user.User) error {
+	if r.fail {
+		return errDown
+	}
+	return r.UserRepository.Create(ctx, u)
+}
+
+// failingCredentials is a credential repository whose Create fails while fail is set
+type failingCredentials struct {
+	*memory.CredentialRepository
+	fail bool
+}
+
+func (r *failingCredentials) Create(ctx context.Context, credential *auth.Credential) error {
+	if r.fail {
+		return errDown
+	}
+	return r.CredentialRepository.Create(ctx, credential)
+}
+
+type accountFixture struct {
+	accounts    *AccountUseCase
+	credentials *failingCredentials
+	users       *failingUsers
+	outbox      *outbox
+}
+
+func newAccountFixture(t *testing.T) *accountFixture {
+	t.Helper()
+
+	keys, err := localauth.GenerateKeySet()
+	if err != nil {
+		t.Fatalf("GenerateKeySet() = %v", err)
+	}
+	provider := localauth.NewProvider(keys, localauth.Config{Issuer: "club", Audience: "club", TokenTTL: time.Hour})
+
+	f := &accountFixture{
+		credentials: &failingCredentials{CredentialRepository: memory.NewCredentialRepository()},
+		users:       &failingUsers{UserRepository: memory.NewUserRepository()},
+		outbox:      &outbox{},
+	}
+	f.accounts = NewAccountUseCase(f.credentials, f.users, localauth.NewBcryptHasher(), provider, f.outbox, resetURL, false)
+	return f
+}
+
+// TestAccountRegisterRollsBack checks that a failed registration leaves
+// neither a user nor a credential behind, so the email can be registered again
+func TestAccountRegisterRollsBack(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(f *accountFixture)
+	}{
+		{"credential not stored", func(f *accountFixture) { f.credentials.fail = true }},
+		{"user not stored", func(f *accountFixture) { f.users.fail = true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newAccountFixture(t)
+
+			tt.fail(f)
+			if _, _, err := f.accounts.Register(ctx, "dora@example.com", "correct horse"); !errors.Is(err, errDown) {
+				t.Fatalf("Register() = %v, want %v", err, errDown)
+			}
+			if _, err := f.credentials.FindByEmail(ctx, "dora@example.com"); !errors.Is(err, auth.ErrCredentialNotFound) {
+				t.Errorf("credential after a failed registration: FindByEmail() = %v, want ErrCredentialNotFound", err)
+			}
+			if _, err := f.users.FindByEmail(ctx, "dora@example.com"); !errors.Is(err, user.ErrUserNotFound) {
+				t.Errorf("user after a failed registration: FindByEmail() = %v, want ErrUserNotFound", err)
+			}
+
+			f.credentials.fail, f.users.fail = false, false
+			registered, _, err := f.accounts.Register(ctx, "dora@example.com", "correct horse")
+			if err != nil {
+				t.Fatalf("Register() after the failure = %v", err)
+			}
+			if _, err := f.users.FindByID(ctx, registered.ID); err != nil {
+				t.Errorf("FindByID() = %v", err)
+			}
+			if _, err := f.accounts.Login(ctx, "dora@example.com", "correct horse"); err != nil {
+				t.Errorf("Login() = %v", err)
+			}
+
+			if _, _, err := f.accounts.Register(ctx, "Dora@Example.com", "another password"); !errors.Is(err, auth.ErrEmailTaken) {
+				t.Errorf("Register() with a taken email = %v, want ErrEmailTaken", err)
+			}
+		})
+	}
+}
+
+// TestAccountResetPassword checks that a reset token works once: changing the
+// password changes the hash fingerprint the token is bound to
+func TestAccountResetPassword(t *testing.T) {
+	ctx := context.Background()
+	f := newAccountFixture(t)
+
+	if _, _, err := f.accounts.Register(ctx, "dora@example.com", "correct horse"); err != nil {
+		t.Fatalf("Register() = %v", err)
+	}
+
+	if err := f.accounts.RequestPasswordReset(ctx, "nobody@example.com"); err != nil {
+		t.Errorf("RequestPasswordReset() for an unknown email = %v, want nil", err)
+	}
+	if len(*f.outbox) != 0 {
+		t.Errorf("%d emails sent for an unknown email, want none", len(*f.outbox))
+	}
+
+	if err := f.accounts.RequestPasswordReset(ctx, "dora@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset() = %v", err)
+	}
+	first := f.outbox.resetToken(t)
+	if err := f.accounts.RequestPasswordReset(ctx, "dora@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset() = %v", err)
+	}
+	second := f.outbox.resetToken(t)
+
+	if err := f.accounts.ResetPassword(ctx, first, "short"); !errors.Is(err, auth.ErrWeakPassword) {
+		t.Errorf("ResetPassword() with a weak password = %v, want ErrWeakPassword", err)
+	}
+	if err := f.accounts.ResetPassword(ctx, first, "battery staple"); err != nil {
+		t.Fatalf("ResetPassword() = %v", err)
+	}
+
+	for name, token := range map[string]string{"used token": first, "token issued before the change": second, "not a token": "junk"} {
+		if err := f.accounts.ResetPassword(ctx, token, "another password"); !errors.Is(err, auth.ErrInvalidResetToken) {
+			t.Errorf("ResetPassword() with a %s = %v, want ErrInvalidResetToken", name, err)
+		}
+	}
+
+	if _, err := f.accounts.Login(ctx, "dora@example.com", "correct horse"); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Errorf("Login() with the old password = %v, want ErrInvalidCredentials", err)
+	}
+	if _, err := f.accounts.Login(ctx, "dora@example.com", "battery staple"); err != nil {
+		t.Errorf("Login() with the new password = %v", err)
+	}
+}
@@ -2,55 +2,237 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
 
 	"github.com/yourusername/toolrentalclub/domain/auth"
 	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+	"github.com/yourusername/toolrentalclub/pkg/tracing"
 )
 
+var tracer = otel.Tracer("github.com/yourusername/toolrentalclub/application/auth")
+
 // UseCase represents the authentication use cases
 type UseCase struct {
 	authService auth.Service
 	userRepo    user.Repository
+	adminIDs    map[string]bool
+
+	// roles looks up the roles the identity provider grants users other
+	// than the principal; nil when the provider grants none
+	roles auth.RoleLookup
+
+	// requireApproval makes users created on first sign-in pending until
+	// staff reinstate them
+	requireApproval bool
 }
 
-// NewUseCase creates a new authentication use case
-func NewUseCase(authService auth.Service, userRepo user.Repository) *UseCase {
+// NewUseCase creates a new authentication use case. The users in
+// adminUserIDs always hold the admin role, which bootstraps the first admin;
+// they never await approval and cannot be locked out by their status. roles
+// may be nil.
+func NewUseCase(authService auth.Service, roles auth.RoleLookup, userRepo user.Repository, adminUserIDs []string, requireApproval bool) *UseCase {
+	adminIDs := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		adminIDs[id] = true
+	}
+
 	return &UseCase{
-		authService: authService,
-		userRepo:    userRepo,
+		authService:     authService,
+		userRepo:        userRepo,
+		adminIDs:        adminIDs,
+		roles:           roles,
+		requireApproval: requireApproval,
 	}
 }
 
 // VerifyTokenAndGetUser verifies a token and returns or creates the user
-func (uc *UseCase) VerifyTokenAndGetUser(ctx context.Context, tokenValue string) (*auth.Token, *user.User, error) {
+func (uc *UseCase) VerifyTokenAndGetUser(ctx context.Context, tokenValue string) (_ *auth.Token, _ *user.User, err error) {
+	ctx, span := tracer.Start(ctx, "auth.VerifyTokenAndGetUser")
+	defer func() { tracing.End(span, err) }()
+
 	// Verify the token
-	token, err := uc.authService.VerifyToken(ctx, tokenValue)
+	token, err := uc.VerifyToken(ctx, tokenValue)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Try to find the user
+	u, err := uc.GetOrCreateUser(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return token, u, nil
+}
+
+// GetOrCreateUser returns the user a verified token identifies, creating it
+// on first sign-in, and refuses users who deleted their account. The user is
+// reconciled with the token's claims, so that an email changed at the
+// identity provider is picked up on the next request.
+func (uc *UseCase) GetOrCreateUser(ctx context.Context, token *auth.Token) (*user.User, error) {
 	existingUser, err := uc.userRepo.FindByID(ctx, token.UserID)
-	if err == nil && existingUser != nil {
-		return token, existingUser, nil
+	if err == nil {
+		// The tombstone of a deleted account is never synced or revived
+		if existingUser.Status == user.StatusDeleted {
+			return nil, user.ErrAccountDeleted
+		}
+		return uc.syncIdentity(ctx, existingUser, identityOf(token))
+	}
+	if !errors.Is(err, user.ErrUserNotFound) {
+		return nil, err
 	}
 
-	// If user doesn't exist, create a new one
+	// The user signs in for the first time; another request may be creating
+	// it concurrently, in which case that user is returned
 	newUser := user.NewUser(token.UserID, token.Email)
+	newUser.SyncIdentity(identityOf(token))
+	if uc.requireApproval && !uc.adminIDs[token.UserID] {
+		newUser.Status = user.StatusPending
+	}
 	if err := uc.userRepo.Create(ctx, newUser); err != nil {
-		// If creation fails, it might be a race condition, try to find again
-		existingUser, findErr := uc.userRepo.FindByID(ctx, token.UserID)
-		if findErr != nil {
-			return nil, nil, err
+		if errors.Is(err, user.ErrUserExists) {
+			return uc.userRepo.FindByID(ctx, token.UserID)
+		}
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("user created on first sign-in", "user_id", newUser.ID, "status", newUser.Status)
+	return newUser, nil
+}
+
+// syncIdentity stores the changes the identity provider's claims make to u.
+// An email that belongs to another account is not taken over: the user
+// keeps their stored email, and the rest of the claims are still applied.
+func (uc *UseCase) syncIdentity(ctx context.Context, u *user.User, id user.Identity) (*user.User, error) {
+	synced := *u
+	if !synced.SyncIdentity(id) {
+		return u, nil
+	}
+
+	err := uc.userRepo.Update(ctx, &synced)
+	if errors.Is(err, user.ErrEmailTaken) {
+		logging.FromContext(ctx).Warn("email from identity provider belongs to another account; keeping the stored email",
+			"user_id", u.ID)
+
+		id.Email, id.EmailVerified = u.Email, u.EmailVerified
+		synced = *u
+		if !synced.SyncIdentity(id) {
+			return u, nil
 		}
-		return token, existingUser, nil
+		err = uc.userRepo.Update(ctx, &synced)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if synced.Email != u.Email {
+		logging.FromContext(ctx).Info("email synced from identity provider", "user_id", u.ID)
 	}
+	return &synced, nil
+}
 
-	return token, newUser, nil
+// identityOf returns the identity a token asserts
+func identityOf(token *auth.Token) user.Identity {
+	return user.Identity{
+		Email:         token.Email,
+		EmailVerified: token.EmailVerified,
+		Name:          token.Name,
+		PictureURL:    token.Picture,
+	}
 }
 
 // VerifyToken verifies a token without user operations
 func (uc *UseCase) VerifyToken(ctx context.Context, tokenValue string) (*auth.Token, error) {
+	if uc.authService == nil {
+		return nil, auth.ErrProviderUnavailable
+	}
 	return uc.authService.VerifyToken(ctx, tokenValue)
 }
 
+// VerifyTokenAndCheckRevoked verifies a token and, when the provider
+// supports it, that it has not been revoked since it was issued
+func (uc *UseCase) VerifyTokenAndCheckRevoked(ctx context.Context, tokenValue string) (_ *auth.Token, err error) {
+	ctx, span := tracer.Start(ctx, "auth.VerifyTokenAndCheckRevoked")
+	defer func() { tracing.End(span, err) }()
+
+	checker, ok := uc.authService.(auth.RevocationChecker)
+	if !ok {
+		return uc.VerifyToken(ctx, tokenValue)
+	}
+	return checker.VerifyTokenAndCheckRevoked(ctx, tokenValue)
+}
+
+// Authenticate verifies a token and returns the principal it identifies,
+// creating the user on first sign-in
+func (uc *UseCase) Authenticate(ctx context.Context, tokenValue string) (_ *auth.Principal, err error) {
+	ctx, span := tracer.Start(ctx, "auth.Authenticate")
+	defer func() { tracing.End(span, err) }()
+
+	token, err := uc.VerifyToken(ctx, tokenValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.PrincipalFor(ctx, token)
+}
+
+// PrincipalFor returns the principal identified by a verified token,
+// creating the user on first sign-in. Its email is the one stored for the
+// user after syncing, and its roles combine the roles stored for the user
+// with those asserted by the identity provider. Users that are not
+// active, such as suspended members, are refused with their status error.
+func (uc *UseCase) PrincipalFor(ctx context.Context, token *auth.Token) (*auth.Principal, error) {
+	u, err := uc.GetOrCreateUser(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !uc.adminIDs[u.ID] {
+		if err := u.CheckActive(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &auth.Principal{
+		UserID:        token.UserID,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Roles:         uc.mergeRoles(u, token.Roles),
+		Provider:      token.Provider,
+		ExpiresAt:     token.ExpiresAt,
+		AuthTime:      token.AuthTime,
+	}, nil
+}
+
+// EffectiveRoles returns the roles a user holds, resolved the way the roles
+// of a principal are: those stored for the user, those the identity provider
+// grants and admin for the users in ADMIN_USER_IDS. The provider's roles are
+// looked up since there is no token to read them from; when that fails the
+// roles cannot be determined and an error is returned.
+func (uc *UseCase) EffectiveRoles(ctx context.Context, u *user.User) (_ []user.Role, err error) {
+	ctx, span := tracer.Start(ctx, "auth.EffectiveRoles")
+	defer func() { tracing.End(span, err) }()
+
+	var granted []string
+	if uc.roles != nil {
+		granted, err = uc.roles.LookupRoles(ctx, u.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", auth.ErrProviderUnavailable, err)
+		}
+	}
+	return uc.mergeRoles(u, granted), nil
+}
+
+// mergeRoles combines the roles stored for u with those the identity
+// provider grants, adding admin for the users in ADMIN_USER_IDS
+func (uc *UseCase) mergeRoles(u *user.User, granted []string) []user.Role {
+	roles := user.MergeRoles(u.Roles, user.ParseRoles(granted))
+	if uc.adminIDs[u.ID] {
+		roles = user.MergeRoles(roles, []user.Role{user.RoleAdmin})
+	}
+	return roles
+}
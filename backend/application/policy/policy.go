@@ -0,0 +1,71 @@
+// Package policy decides what an authenticated user may do. Use cases
+// consult it before acting on behalf of a principal, so that the rules live
+// in one place rather than in every handler.
+package policy
+
+import (
+	"slices"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// CanRentTools reports whether the principal may request rentals
+func CanRentTools(p *auth.Principal) bool {
+	return p.Can(user.PermissionRentTools)
+}
+
+// CanCreateTool reports whether the principal may add tools to the catalog
+func CanCreateTool(p *auth.Principal) bool {
+	return p.Can(user.PermissionListTools)
+}
+
+// CanManageTool reports whether the principal may edit, delete or block a
+// tool: its owner, or staff
+func CanManageTool(p *auth.Principal, t *tool.Tool) bool {
+	return t.IsOwnedBy(p.UserID) || p.Can(user.PermissionManageAnyTool)
+}
+
+// CanViewRental reports whether the principal may see a rental: the renter,
+// the tool owner, or staff
+func CanViewRental(p *auth.Principal, r *rental.Rental, t *tool.Tool) bool {
+	return r.RenterID == p.UserID || CanManageRental(p, t)
+}
+
+// CanManageRental reports whether the principal may confirm a rental and
+// record its pick-up and return: the tool owner, or staff
+func CanManageRental(p *auth.Principal, t *tool.Tool) bool {
+	return t.IsOwnedBy(p.UserID) || p.Can(user.PermissionManageAnyRental)
+}
+
+// CanCancelRental reports whether the principal may cancel a rental: the
+// renter, the tool owner, or staff
+func CanCancelRental(p *auth.Principal, r *rental.Rental, t *tool.Tool) bool {
+	return r.RenterID == p.UserID || CanManageRental(p, t)
+}
+
+// CanViewUsers reports whether the principal may look up other users
+func CanViewUsers(p *auth.Principal) bool {
+	return p.Can(user.PermissionViewUsers)
+}
+
+// CanManageRoles reports whether the principal may grant and revoke roles
+func CanManageRoles(p *auth.Principal) bool {
+	return p.Can(user.PermissionManageRoles)
+}
+
+// CanManageMember reports whether the principal may change the account
+// status of a user holding the given roles: staff for members and tool
+// owners, admins for anyone. The roles must be the target's effective roles,
+// including those granted by the identity provider, not only stored ones.
+func CanManageMember(p *auth.Principal, targetRoles []user.Role) bool {
+	if !p.Can(user.PermissionManageMembers) {
+		return false
+	}
+	if slices.Contains(targetRoles, user.RoleStaff) || slices.Contains(targetRoles, user.RoleAdmin) {
+		return CanManageRoles(p)
+	}
+	return true
+}
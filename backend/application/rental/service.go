@@ -0,0 +1,185 @@
+package rental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/toolrentalclub/application/policy"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// UseCase represents the reservation use cases
+type UseCase struct {
+	rentalRepo rental.Repository
+	toolRepo   tool.Repository
+	userRepo   user.Repository
+}
+
+// NewUseCase creates a new rental use case
+func NewUseCase(rentalRepo rental.Repository, toolRepo tool.Repository, userRepo user.Repository) *UseCase {
+	return &UseCase{
+		rentalRepo: rentalRepo,
+		toolRepo:   toolRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// RequestRental reserves a tool for the authenticated user over [start, end)
+func (uc *UseCase) RequestRental(ctx context.Context, toolID string, start, end time.Time) (*rental.Rental, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanRentTools(principal) {
+		return nil, rental.ErrForbidden
+	}
+
+	t, err := uc.toolRepo.FindByID(ctx, toolID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status != tool.StatusAvailable {
+		return nil, rental.ErrToolUnavailable
+	}
+
+	rent := rental.NewRental(uuid.NewString(), toolID, principal.UserID, start, end)
+	if err := rent.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The repository rejects the reservation atomically if it overlaps a
+	// confirmed one or a maintenance or blackout block
+	if err := uc.rentalRepo.Create(ctx, rent); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("rental requested", "rental_id", rent.ID, "tool_id", rent.ToolID)
+	return rent, nil
+}
+
+// GetRental retrieves a rental visible to the authenticated user (the renter,
+// the tool owner or staff)
+func (uc *UseCase) GetRental(ctx context.Context, id string) (*rental.Rental, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rent, t, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanViewRental(principal, rent, t) {
+		return nil, rental.ErrForbidden
+	}
+
+	return rent, nil
+}
+
+// ConfirmRental lets the tool owner or staff accept a requested reservation
+func (uc *UseCase) ConfirmRental(ctx context.Context, id string) (*rental.Rental, error) {
+	return uc.transition(ctx, id, rental.StatusConfirmed, false)
+}
+
+// PickUpRental lets the tool owner or staff record that the renter collected the tool
+func (uc *UseCase) PickUpRental(ctx context.Context, id string) (*rental.Rental, error) {
+	return uc.transition(ctx, id, rental.StatusPickedUp, false)
+}
+
+// ReturnRental lets the tool owner or staff record that the tool came back
+func (uc *UseCase) ReturnRental(ctx context.Context, id string) (*rental.Rental, error) {
+	return uc.transition(ctx, id, rental.StatusReturned, false)
+}
+
+// CancelRental lets the renter, the tool owner or staff cancel a reservation
+func (uc *UseCase) CancelRental(ctx context.Context, id string) (*rental.Rental, error) {
+	return uc.transition(ctx, id, rental.StatusCancelled, true)
+}
+
+// transition moves a rental to the next state on behalf of the authenticated
+// user. The tool owner and staff may always act; the renter only when
+// renterAllowed is set.
+func (uc *UseCase) transition(ctx context.Context, id string, next rental.Status, renterAllowed bool) (*rental.Rental, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rent, t, err := uc.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := policy.CanManageRental(principal, t)
+	if renterAllowed {
+		allowed = policy.CanCancelRental(principal, rent, t)
+	}
+	if !allowed {
+		return nil, rental.ErrForbidden
+	}
+
+	prev := rent.Status
+	if err := rent.TransitionTo(next); err != nil {
+		return nil, err
+	}
+
+	// Tools are only promised to and handed over to members in good standing
+	if next == rental.StatusConfirmed || next == rental.StatusPickedUp {
+		if err := uc.checkRenter(ctx, rent); err != nil {
+			return nil, err
+		}
+	}
+
+	// Confirming is checked against other confirmed reservations and the
+	// tool's blocks atomically by the repository, which also rejects the
+	// write if another transition changed the rental since it was loaded
+	if err := uc.rentalRepo.Update(ctx, rent, prev); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("rental status changed", "rental_id", rent.ID, "status", string(rent.Status))
+	return rent, nil
+}
+
+// checkRenter rejects a rental whose renter's account is not active. The
+// renter's reason for a suspension is not disclosed to the tool owner.
+func (uc *UseCase) checkRenter(ctx context.Context, rent *rental.Rental) error {
+	renter, err := uc.userRepo.FindByID(ctx, rent.RenterID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return rental.ErrRenterInactive
+	}
+	if err != nil {
+		return err
+	}
+
+	if status := renter.EffectiveStatus(time.Now()); status != user.StatusActive {
+		return fmt.Errorf("%w: %s", rental.ErrRenterInactive, status)
+	}
+	return nil
+}
+
+// load retrieves a rental together with the tool it reserves
+func (uc *UseCase) load(ctx context.Context, id string) (*rental.Rental, *tool.Tool, error) {
+	rent, err := uc.rentalRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := uc.toolRepo.FindByID(ctx, rent.ToolID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rent, t, nil
+}
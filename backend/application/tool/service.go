@@ -0,0 +1,270 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/toolrentalclub/application/policy"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// ToolInput holds the editable attributes of a tool
+type ToolInput struct {
+	Name                  string
+	Description           string
+	Category              tool.Category
+	Condition             tool.Condition
+	ReplacementValueCents int64
+	DailyRateCents        int64
+	Status                tool.Status
+}
+
+// BlockInput holds the attributes of a maintenance or blackout block
+type BlockInput struct {
+	Kind   tool.BlockKind
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// UseCase represents the tool catalog use cases
+type UseCase struct {
+	toolRepo   tool.Repository
+	blockRepo  tool.BlockRepository
+	rentalRepo rental.Repository
+}
+
+// NewUseCase creates a new tool use case
+func NewUseCase(toolRepo tool.Repository, blockRepo tool.BlockRepository, rentalRepo rental.Repository) *UseCase {
+	return &UseCase{
+		toolRepo:   toolRepo,
+		blockRepo:  blockRepo,
+		rentalRepo: rentalRepo,
+	}
+}
+
+// ListTools retrieves every tool in the catalog
+func (uc *UseCase) ListTools(ctx context.Context) ([]*tool.Tool, error) {
+	return uc.toolRepo.FindAll(ctx)
+}
+
+// GetTool retrieves a tool by its ID
+func (uc *UseCase) GetTool(ctx context.Context, id string) (*tool.Tool, error) {
+	return uc.toolRepo.FindByID(ctx, id)
+}
+
+// CreateTool adds a new tool owned by the authenticated user to the catalog
+func (uc *UseCase) CreateTool(ctx context.Context, input ToolInput) (*tool.Tool, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanCreateTool(principal) {
+		return nil, tool.ErrCannotList
+	}
+
+	t := tool.NewTool(uuid.NewString(), principal.UserID, input.Name)
+	applyInput(t, input)
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.toolRepo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// UpdateTool replaces the editable attributes of a tool the authenticated user manages
+func (uc *UseCase) UpdateTool(ctx context.Context, id string, input ToolInput) (*tool.Tool, error) {
+	t, err := uc.managedTool(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyInput(t, input)
+	t.UpdatedAt = time.Now()
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.toolRepo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// DeleteTool removes a tool the authenticated user manages from the catalog.
+// A tool with confirmed or picked-up rentals cannot be removed until they are
+// settled. A tool that has been rented before is retired rather than deleted,
+// so that its rentals stay viewable; its requested rentals are cancelled.
+func (uc *UseCase) DeleteTool(ctx context.Context, id string) error {
+	t, err := uc.managedTool(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rentals, err := uc.rentalRepo.FindByToolID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, rent := range rentals {
+		if rent.Status.Blocking() {
+			return fmt.Errorf("%w: rental %s is %s", tool.ErrActiveRentals, rent.ID, rent.Status)
+		}
+	}
+
+	if len(rentals) == 0 {
+		return uc.toolRepo.Delete(ctx, id)
+	}
+
+	for _, rent := range rentals {
+		if rent.Status != rental.StatusRequested {
+			continue
+		}
+		if err := rent.TransitionTo(rental.StatusCancelled); err != nil {
+			return err
+		}
+		// A request confirmed in the meantime fails the conditional update
+		if err := uc.rentalRepo.Update(ctx, rent, rental.StatusRequested); err != nil {
+			if errors.Is(err, rental.ErrInvalidTransition) {
+				return fmt.Errorf("%w: rental %s changed while the tool was being removed", tool.ErrActiveRentals, rent.ID)
+			}
+			return err
+		}
+	}
+
+	t.Status = tool.StatusRetired
+	t.UpdatedAt = time.Now()
+	if err := uc.toolRepo.Update(ctx, t); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("tool retired instead of deleted", "tool_id", t.ID, "rentals", len(rentals))
+	return nil
+}
+
+// GetAvailability computes the free and busy intervals of a tool within
+// [from, to) from its blocking reservations and its maintenance and blackout blocks
+func (uc *UseCase) GetAvailability(ctx context.Context, id string, from, to time.Time) ([]tool.Interval, error) {
+	if _, err := uc.toolRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rentals, err := uc.rentalRepo.FindByToolID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := uc.blockRepo.FindByToolID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]tool.Interval, 0, len(rentals)+len(blocks))
+	for _, rent := range rentals {
+		if rent.Status.Blocking() {
+			busy = append(busy, tool.BusyInterval(rent.Start, rent.End, tool.ReasonReservation))
+		}
+	}
+	for _, b := range blocks {
+		busy = append(busy, tool.BusyInterval(b.Start, b.End, tool.BusyReason(b.Kind)))
+	}
+
+	return tool.Availability(from, to, busy), nil
+}
+
+// ListBlocks retrieves the maintenance and blackout blocks of a tool the authenticated user manages
+func (uc *UseCase) ListBlocks(ctx context.Context, id string) ([]*tool.Block, error) {
+	if _, err := uc.managedTool(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return uc.blockRepo.FindByToolID(ctx, id)
+}
+
+// AddBlock blocks a tool the authenticated user manages from being rented for a period
+func (uc *UseCase) AddBlock(ctx context.Context, id string, input BlockInput) (*tool.Block, error) {
+	if _, err := uc.managedTool(ctx, id); err != nil {
+		return nil, err
+	}
+
+	b := tool.NewBlock(uuid.NewString(), id, input.Kind, input.Start, input.End, input.Reason)
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.blockRepo.Create(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// RemoveBlock deletes a block from a tool the authenticated user manages
+func (uc *UseCase) RemoveBlock(ctx context.Context, id, blockID string) error {
+	if _, err := uc.managedTool(ctx, id); err != nil {
+		return err
+	}
+
+	blocks, err := uc.blockRepo.FindByToolID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range blocks {
+		if b.ID == blockID {
+			return uc.blockRepo.Delete(ctx, blockID)
+		}
+	}
+
+	return tool.ErrBlockNotFound
+}
+
+// managedTool retrieves a tool and checks that the authenticated user may manage it
+func (uc *UseCase) managedTool(ctx context.Context, id string) (*tool.Tool, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := uc.toolRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanManageTool(principal, t) {
+		return nil, tool.ErrNotOwner
+	}
+
+	return t, nil
+}
+
+// applyInput copies the editable attributes onto t, keeping the current
+// condition and status when the input leaves them empty
+func applyInput(t *tool.Tool, input ToolInput) {
+	t.Name = input.Name
+	t.Description = input.Description
+	t.Category = input.Category
+	t.ReplacementValueCents = input.ReplacementValueCents
+	t.DailyRateCents = input.DailyRateCents
+	if input.Condition != "" {
+		t.Condition = input.Condition
+	}
+	if input.Status != "" {
+		t.Status = input.Status
+	}
+}
@@ -0,0 +1,257 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// DataExport holds everything the club stores about a member
+type DataExport struct {
+	ExportedAt time.Time
+	User       *user.User
+	Credential *auth.Credential // nil unless the member signs in with a password
+	Rentals    []*rental.Rental // rentals the member made
+	Tools      []*tool.Tool     // tools the member lends through the club
+	Lent       []*rental.Rental // rentals other members made of the member's tools
+}
+
+// ExportData gathers everything the club stores about the authenticated user
+func (uc *UseCase) ExportData(ctx context.Context) (*DataExport, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := uc.credentialRepo.FindByUserID(ctx, u.ID)
+	if errors.Is(err, auth.ErrCredentialNotFound) {
+		credential, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rentals, err := uc.rentalRepo.FindByRenterID(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tools, err := uc.ownedTools(ctx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lent, err := uc.lentRentals(ctx, u.ID, tools)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("user data exported")
+	return &DataExport{
+		ExportedAt: time.Now(),
+		User:       u,
+		Credential: credential,
+		Rentals:    rentals,
+		Tools:      tools,
+		Lent:       lent,
+	}, nil
+}
+
+// DeleteAccount removes the authenticated user and everything that
+// identifies them. Records other members rely on are kept but pseudonymized:
+// past rentals and the member's tools, which are retired from the catalog,
+// refer to a random pseudonym instead of the user. Requested rentals are
+// cancelled; confirmed or picked-up ones must be settled first. The user is
+// replaced by a tombstone, so that their tokens cannot recreate the account.
+//
+// The steps are separate writes. Tools are retired before the rentals are
+// checked a second time, so that a rental requested or confirmed in between
+// is caught; if the deletion is rejected then, the tools stay retired.
+func (uc *UseCase) DeleteAccount(ctx context.Context) error {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return err
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	tools, err := uc.ownedTools(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+
+	rentals, err := uc.memberRentals(ctx, u.ID, tools)
+	if err != nil {
+		return err
+	}
+	if err := checkSettled(rentals); err != nil {
+		return err
+	}
+	if err := uc.cancelRequested(ctx, rentals); err != nil {
+		return err
+	}
+
+	// No new rentals of retired tools can be requested
+	for _, t := range tools {
+		t.Status = tool.StatusRetired
+		t.UpdatedAt = time.Now()
+		if err := uc.toolRepo.Update(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	rentals, err = uc.memberRentals(ctx, u.ID, tools)
+	if err != nil {
+		return err
+	}
+	if err := checkSettled(rentals); err != nil {
+		return err
+	}
+	if err := uc.cancelRequested(ctx, rentals); err != nil {
+		return err
+	}
+
+	pseudonym := user.DeletedUserIDPrefix + uuid.NewString()
+
+	rented := 0
+	for _, rent := range rentals {
+		if rent.RenterID != u.ID {
+			continue // lent to another member; the rental does not name the owner
+		}
+		rent.RenterID = pseudonym
+		rent.UpdatedAt = time.Now()
+		if err := uc.rentalRepo.Update(ctx, rent, rent.Status); err != nil {
+			return err
+		}
+		rented++
+	}
+
+	for _, t := range tools {
+		t.OwnerID = pseudonym
+		t.UpdatedAt = time.Now()
+		if err := uc.toolRepo.Update(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.credentialRepo.Delete(ctx, u.ID); err != nil && !errors.Is(err, auth.ErrCredentialNotFound) {
+		return err
+	}
+
+	u.Erase()
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return err
+	}
+
+	logger := logging.FromContext(ctx)
+	logger.Info("user account deleted", "pseudonym", pseudonym, "rentals", rented, "tools", len(tools))
+
+	// The club's data is gone either way; a provider account left behind
+	// can only sign in to the tombstone, which refuses it
+	if uc.accounts != nil {
+		if err := uc.accounts.DeleteAccount(ctx, u.ID); err != nil {
+			logger.Error("failed to delete identity provider account; delete it manually", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// memberRentals returns the rentals a user made and the rentals of their
+// tools, each once
+func (uc *UseCase) memberRentals(ctx context.Context, userID string, tools []*tool.Tool) ([]*rental.Rental, error) {
+	rentals, err := uc.rentalRepo.FindByRenterID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lent, err := uc.lentRentals(ctx, userID, tools)
+	if err != nil {
+		return nil, err
+	}
+	return append(rentals, lent...), nil
+}
+
+// lentRentals returns the rentals other members made of the given tools of
+// the user. Rentals the user made of their own tools are left out, since
+// FindByRenterID already returns them.
+func (uc *UseCase) lentRentals(ctx context.Context, userID string, tools []*tool.Tool) ([]*rental.Rental, error) {
+	var lent []*rental.Rental
+	for _, t := range tools {
+		rentals, err := uc.rentalRepo.FindByToolID(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rent := range rentals {
+			if rent.RenterID != userID {
+				lent = append(lent, rent)
+			}
+		}
+	}
+	return lent, nil
+}
+
+// checkSettled rejects an account deletion while any of the rentals is
+// confirmed or picked up
+func checkSettled(rentals []*rental.Rental) error {
+	for _, rent := range rentals {
+		if rent.Status.Blocking() {
+			return fmt.Errorf("%w: rental %s is %s", user.ErrActiveRentals, rent.ID, rent.Status)
+		}
+	}
+	return nil
+}
+
+// cancelRequested cancels the rentals that are still only requested. A
+// rental confirmed since it was loaded fails the conditional update and
+// rejects the deletion.
+func (uc *UseCase) cancelRequested(ctx context.Context, rentals []*rental.Rental) error {
+	for _, rent := range rentals {
+		if rent.Status != rental.StatusRequested {
+			continue
+		}
+		if err := rent.TransitionTo(rental.StatusCancelled); err != nil {
+			return err
+		}
+		if err := uc.rentalRepo.Update(ctx, rent, rental.StatusRequested); err != nil {
+			if errors.Is(err, rental.ErrInvalidTransition) {
+				return fmt.Errorf("%w: rental %s changed while the account was being deleted", user.ErrActiveRentals, rent.ID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ownedTools returns the tools a user lends through the club
+func (uc *UseCase) ownedTools(ctx context.Context, userID string) ([]*tool.Tool, error) {
+	all, err := uc.toolRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*tool.Tool
+	for _, t := range all {
+		if t.IsOwnedBy(userID) {
+			owned = append(owned, t)
+		}
+	}
+	return owned, nil
+}
@@ -2,20 +2,116 @@ package user
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/yourusername/toolrentalclub/application/policy"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
 	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
 )
 
 // UseCase represents the user use cases
 type UseCase struct {
-	userRepo user.Repository
+	userRepo       user.Repository
+	rentalRepo     rental.Repository
+	toolRepo       tool.Repository
+	credentialRepo auth.CredentialRepository
+
+	// accounts deletes the identity provider account of a member who leaves;
+	// nil keeps provider accounts
+	accounts auth.AccountDeleter
+
+	// roles resolves the effective roles of the users staff act on
+	roles RoleResolver
 }
 
-// NewUseCase creates a new user use case
-func NewUseCase(userRepo user.Repository) *UseCase {
+// RoleResolver resolves the effective roles of a user, including roles
+// granted outside the club's records such as by the identity provider
+type RoleResolver interface {
+	EffectiveRoles(ctx context.Context, u *user.User) ([]user.Role, error)
+}
+
+// NewUseCase creates a new user use case. accounts may be nil.
+func NewUseCase(
+	userRepo user.Repository,
+	rentalRepo rental.Repository,
+	toolRepo tool.Repository,
+	credentialRepo auth.CredentialRepository,
+	accounts auth.AccountDeleter,
+	roles RoleResolver,
+) *UseCase {
 	return &UseCase{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		rentalRepo:     rentalRepo,
+		toolRepo:       toolRepo,
+		credentialRepo: credentialRepo,
+		accounts:       accounts,
+		roles:          roles,
+	}
+}
+
+// GetCurrentUser retrieves the authenticated user
+func (uc *UseCase) GetCurrentUser(ctx context.Context) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.userRepo.FindByID(ctx, principal.UserID)
+}
+
+// ProfileInput holds a partial update of a profile. Nil fields are left
+// unchanged; an empty string clears a field.
+type ProfileInput struct {
+	DisplayName   *string
+	Phone         *string
+	Address       *AddressInput
+	AvatarURL     *string
+	Bio           *string
+	Notifications *NotificationInput
+}
+
+// AddressInput holds a partial update of a postal address
+type AddressInput struct {
+	Line1      *string
+	Line2      *string
+	City       *string
+	Region     *string
+	PostalCode *string
+	Country    *string
+}
+
+// NotificationInput holds a partial update of notification preferences
+type NotificationInput struct {
+	RentalUpdates *bool
+	Reminders     *bool
+	SMS           *bool
+	Newsletter    *bool
+}
+
+// UpdateProfile applies a partial update to the authenticated user's profile
+func (uc *UseCase) UpdateProfile(ctx context.Context, input ProfileInput) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
 	}
+
+	if err := u.UpdateProfile(applyProfileInput(u.Profile, input)); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
 }
 
 // GetUserByID retrieves a user by their ID
@@ -28,3 +124,129 @@ func (uc *UseCase) GetUserByEmail(ctx context.Context, email string) (*user.User
 	return uc.userRepo.FindByEmail(ctx, email)
 }
 
+// LookupUser retrieves another user on behalf of staff
+func (uc *UseCase) LookupUser(ctx context.Context, id string) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanViewUsers(principal) {
+		return nil, user.ErrForbidden
+	}
+
+	return uc.userRepo.FindByID(ctx, id)
+}
+
+// ListUsers retrieves a page of the club's users on behalf of staff
+func (uc *UseCase) ListUsers(ctx context.Context, query user.ListQuery) (*user.ListPage, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanViewUsers(principal) {
+		return nil, user.ErrForbidden
+	}
+
+	return uc.userRepo.List(ctx, query)
+}
+
+// GrantRole gives a user a role on behalf of an admin
+func (uc *UseCase) GrantRole(ctx context.Context, id string, role user.Role) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanManageRoles(principal) {
+		return nil, user.ErrForbidden
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.GrantRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("role granted", "target_user_id", u.ID, "role", string(role))
+	return u, nil
+}
+
+// RevokeRole takes a role away from a user on behalf of an admin. Admins
+// cannot revoke their own admin role, so the club always keeps one.
+func (uc *UseCase) RevokeRole(ctx context.Context, id string, role user.Role) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !policy.CanManageRoles(principal) {
+		return nil, user.ErrForbidden
+	}
+
+	if id == principal.UserID && role == user.RoleAdmin {
+		return nil, fmt.Errorf("%w: admins cannot revoke their own admin role", user.ErrForbidden)
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.RevokeRole(role); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("role revoked", "target_user_id", u.ID, "role", string(role))
+	return u, nil
+}
+
+// applyProfileInput returns p with the fields set in input replaced
+func applyProfileInput(p user.Profile, input ProfileInput) user.Profile {
+	setString(&p.DisplayName, input.DisplayName)
+	setString(&p.Phone, input.Phone)
+	setString(&p.AvatarURL, input.AvatarURL)
+	setString(&p.Bio, input.Bio)
+
+	if a := input.Address; a != nil {
+		setString(&p.Address.Line1, a.Line1)
+		setString(&p.Address.Line2, a.Line2)
+		setString(&p.Address.City, a.City)
+		setString(&p.Address.Region, a.Region)
+		setString(&p.Address.PostalCode, a.PostalCode)
+		setString(&p.Address.Country, a.Country)
+	}
+
+	if n := input.Notifications; n != nil {
+		setBool(&p.Notifications.RentalUpdates, n.RentalUpdates)
+		setBool(&p.Notifications.Reminders, n.Reminders)
+		setBool(&p.Notifications.SMS, n.SMS)
+		setBool(&p.Notifications.Newsletter, n.Newsletter)
+	}
+
+	return p
+}
+
+func setString(field *string, value *string) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+func setBool(field *bool, value *bool) {
+	if value != nil {
+		*field = *value
+	}
+}
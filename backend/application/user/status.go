@@ -0,0 +1,75 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/application/policy"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// SuspendUser bars a user from the club on behalf of staff, until the given
+// time or indefinitely if until is zero. The reason is shown to the user.
+func (uc *UseCase) SuspendUser(ctx context.Context, id, reason string, until time.Time) (*user.User, error) {
+	return uc.changeStatus(ctx, id, "account suspended", func(u *user.User) error {
+		return u.Suspend(reason, until)
+	})
+}
+
+// ReinstateUser makes a user active on behalf of staff: it approves a
+// pending account, lifts a suspension or reopens a closed account
+func (uc *UseCase) ReinstateUser(ctx context.Context, id string) (*user.User, error) {
+	return uc.changeStatus(ctx, id, "account reinstated", func(u *user.User) error {
+		return u.Reinstate()
+	})
+}
+
+// CloseUser shuts a user's account on behalf of staff. Unlike account
+// deletion the user's data is kept, and the account can be reinstated.
+func (uc *UseCase) CloseUser(ctx context.Context, id, reason string) (*user.User, error) {
+	return uc.changeStatus(ctx, id, "account closed", func(u *user.User) error {
+		return u.Close(reason)
+	})
+}
+
+// changeStatus applies a status change to another user and logs it. Staff
+// cannot change their own status, which would lock them out.
+func (uc *UseCase) changeStatus(ctx context.Context, id, action string, change func(*user.User) error) (*user.User, error) {
+	principal, err := auth.RequirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == principal.UserID {
+		return nil, fmt.Errorf("%w: users cannot change their own account status", user.ErrForbidden)
+	}
+
+	u, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Staff must not act on a user who is staff or admin only through the
+	// identity provider or ADMIN_USER_IDS
+	roles, err := uc.roles.EffectiveRoles(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanManageMember(principal, roles) {
+		return nil, user.ErrForbidden
+	}
+
+	if err := change(u); err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info(action, "target_user_id", u.ID, "status", string(u.Status), "reason", u.StatusReason)
+	return u, nil
+}
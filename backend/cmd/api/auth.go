@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	firebaseSDK "firebase.google.com/go/v4"
+	"github.com/gorilla/mux"
+
+	authApp "github.com/yourusername/toolrentalclub/application/auth"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/mail"
+	"github.com/yourusername/toolrentalclub/infrastructure/firebase"
+	"github.com/yourusername/toolrentalclub/infrastructure/localauth"
+	mailInfra "github.com/yourusername/toolrentalclub/infrastructure/mail"
+	"github.com/yourusername/toolrentalclub/infrastructure/tokencache"
+	"github.com/yourusername/toolrentalclub/interfaces/http/middleware"
+	"github.com/yourusername/toolrentalclub/pkg/config"
+	"github.com/yourusername/toolrentalclub/pkg/health"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+	"github.com/yourusername/toolrentalclub/pkg/server"
+)
+
+// authProvider holds the auth service selected by the configuration
+type authProvider struct {
+	service auth.Service
+
+	// accounts and keys are only set for the self-hosted jwt provider
+	accounts *authApp.AccountUseCase
+	keys     auth.KeyPublisher
+
+	// deleter deletes provider accounts of members who leave; only set for
+	// Firebase with AUTH_DELETE_PROVIDER_ACCOUNTS
+	deleter auth.AccountDeleter
+
+	// roles looks up the roles Firebase grants users through custom claims;
+	// nil for providers whose roles are all stored by the club
+	roles auth.RoleLookup
+
+	// check verifies the provider can authenticate requests and mailCheck
+	// that outgoing email can be delivered; nil when there is nothing to check
+	check     health.Check
+	mailCheck health.Check
+
+	// close releases what the auth service holds when the server stops; nil
+	// when it holds nothing
+	close server.ShutdownHook
+}
+
+// closer is implemented by auth services that hold resources
+type closer interface {
+	Close(ctx context.Context) error
+}
+
+// newAuthProvider creates the auth service for the configured AUTH_MODE,
+// behind the verified token cache unless AUTH_TOKEN_CACHE_SIZE is 0.
+func newAuthProvider(ctx context.Context, cfg *config.Config, firebaseApp *firebaseSDK.App, repos *repositories, m *metrics.Metrics) (*authProvider, error) {
+	p, err := newAuthService(ctx, cfg, firebaseApp, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.service != nil && cfg.AuthTokenCacheSize > 0 {
+		p.service = tokencache.New(p.service, cfg.AuthTokenCacheSize, m)
+	}
+	if c, ok := p.service.(closer); ok {
+		p.close = c.Close
+	}
+	return p, nil
+}
+
+// newAuthService creates the auth service for the configured AUTH_MODE.
+// It refuses to start when the selected provider is unavailable; service is
+// nil only in the dev-insecure and none modes, which verify no tokens.
+func newAuthService(ctx context.Context, cfg *config.Config, firebaseApp *firebaseSDK.App, repos *repositories) (*authProvider, error) {
+	switch cfg.AuthMode {
+	case config.AuthModeFirebase:
+		if firebaseApp == nil {
+			return nil, fmt.Errorf("AUTH_MODE=firebase but Firebase is not initialized; set FIREBASE_SERVICE_ACCOUNT or FIREBASE_CREDENTIALS_JSON, or choose another AUTH_MODE")
+		}
+		service, err := firebase.NewAuthService(ctx, firebaseApp)
+		if err != nil {
+			return nil, err
+		}
+		p := &authProvider{service: service, roles: service}
+		if cfg.AuthDeleteProviderAccounts {
+			p.deleter = service
+		}
+		return p, nil
+
+	case config.AuthModeJWT:
+		keys, err := loadKeySet(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		provider := localauth.NewProvider(keys, localauth.Config{
+			Issuer:   cfg.JWTIssuer,
+			Audience: cfg.JWTAudience,
+			TokenTTL: cfg.JWTTokenTTL,
+		})
+		mailer := newMailSender(cfg)
+		accounts := authApp.NewAccountUseCase(
+			repos.credentials,
+			repos.users,
+			localauth.NewBcryptHasher(),
+			provider,
+			mailer,
+			cfg.PasswordResetURL,
+			cfg.RequireMemberApproval,
+		)
+
+		log.Printf("Using self-hosted JWT auth (issuer %s, signing key %s)", cfg.JWTIssuer, keys.ActiveKeyID())
+		p := &authProvider{service: provider, accounts: accounts, keys: provider, check: provider.Check}
+		if checker, ok := mailer.(health.Checker); ok {
+			p.mailCheck = checker.Check
+		}
+		return p, nil
+
+	case config.AuthModeDevInsecure, config.AuthModeNone:
+		return &authProvider{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown AUTH_MODE %q (expected firebase, jwt, dev-insecure or none)", cfg.AuthMode)
+}
+
+// newAuthMiddleware creates the middleware that guards protected routes in
+// the configured AUTH_MODE
+func newAuthMiddleware(cfg *config.Config, authUseCase *authApp.UseCase, m *metrics.Metrics) mux.MiddlewareFunc {
+	switch cfg.AuthMode {
+	case config.AuthModeDevInsecure:
+		logDevBanner(cfg)
+		return middleware.DevAuthMiddleware(authUseCase, cfg.DevUserID, cfg.DevEmail, cfg.DevRoles)
+
+	case config.AuthModeNone:
+		log.Println("WARNING: AUTH_MODE=none; every protected route will reject requests.")
+		return middleware.DenyAllMiddleware
+	}
+
+	return middleware.AuthMiddleware(authUseCase, m)
+}
+
+// newRevocationCheck creates the middleware that rejects revoked tokens on
+// sensitive routes. Only Firebase can revoke tokens; in other modes, or with
+// AUTH_CHECK_REVOKED=false, it returns nil and the routes skip the check.
+func newRevocationCheck(cfg *config.Config, authUseCase *authApp.UseCase, m *metrics.Metrics) mux.MiddlewareFunc {
+	if cfg.AuthMode != config.AuthModeFirebase || !cfg.AuthCheckRevoked {
+		return nil
+	}
+	return middleware.RevocationCheckMiddleware(authUseCase, m)
+}
+
+// logDevBanner warns loudly that dev-insecure mode lets anyone act as any user
+func logDevBanner(cfg *config.Config) {
+	lines := []string{
+		"************************************************************************",
+		"*  AUTH_MODE=dev-insecure: AUTHENTICATION IS DISABLED                  *",
+		"*  Any client can act as any user by setting the " + middleware.DevUserIDHeader + " header. *",
+		"*  NEVER use this mode on a server reachable by others.                *",
+		"************************************************************************",
+	}
+	for _, line := range lines {
+		log.Println(line)
+	}
+	log.Printf("Default dev identity: %s <%s> roles %v", cfg.DevUserID, cfg.DevEmail, cfg.DevRoles)
+}
+
+func loadKeySet(cfg *config.Config) (*localauth.KeySet, error) {
+	if cfg.JWTKeysDir == "" {
+		log.Println("WARNING: JWT_KEYS_DIR not set; using an ephemeral signing key. Tokens will not survive a restart.")
+		return localauth.GenerateKeySet()
+	}
+
+	keys, err := localauth.LoadKeySet(cfg.JWTKeysDir, cfg.JWTActiveKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+	return keys, nil
+}
+
+func newMailSender(cfg *config.Config) mail.Sender {
+	if cfg.SMTPHost == "" {
+		log.Println("WARNING: SMTP_HOST not set; outgoing emails will be written to the log.")
+		return mailInfra.NewLogSender()
+	}
+
+	return mailInfra.NewSMTPSender(mailInfra.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.MailFrom,
+	})
+}
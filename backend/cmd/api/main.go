@@ -2,66 +2,183 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	authApp "github.com/yourusername/toolrentalclub/application/auth"
+	rentalApp "github.com/yourusername/toolrentalclub/application/rental"
+	toolApp "github.com/yourusername/toolrentalclub/application/tool"
 	userApp "github.com/yourusername/toolrentalclub/application/user"
 	"github.com/yourusername/toolrentalclub/infrastructure/firebase"
-	"github.com/yourusername/toolrentalclub/infrastructure/repository/memory"
 	"github.com/yourusername/toolrentalclub/interfaces/http/handlers"
+	"github.com/yourusername/toolrentalclub/interfaces/http/middleware"
 	"github.com/yourusername/toolrentalclub/interfaces/http/routes"
 	"github.com/yourusername/toolrentalclub/pkg/config"
+	"github.com/yourusername/toolrentalclub/pkg/health"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
 	"github.com/yourusername/toolrentalclub/pkg/server"
+	"github.com/yourusername/toolrentalclub/pkg/tracing"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
-	// Initialize Firebase
+	// Initialize structured logging; the standard log package writes through it too
+	logger, err := logging.New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Failed to configure logging: %v", err)
+	}
+	slog.SetDefault(logger)
+
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to parse TRUSTED_PROXIES: %v", err)
+	}
+
+	corsPolicy, err := middleware.NewCORSPolicy(middleware.CORSConfig{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowedMethods:   cfg.CORSAllowedMethods,
+		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   cfg.CORSExposedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})
+	if err != nil {
+		log.Fatalf("Failed to configure CORS: %v", err)
+	}
+
+	// Start the server lifecycle; hooks registered with it run in reverse
+	// order on shutdown
+	srv := server.New(server.Config{
+		Port:            cfg.Port,
+		ReadTimeout:     15 * time.Second,
+		WriteTimeout:    15 * time.Second,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+		ShutdownDelay:   cfg.ShutdownDelay,
+	})
+
+	// Initialize tracing; spans are flushed after everything else has stopped
 	ctx := context.Background()
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.TracingExporter,
+		ServiceName: cfg.TracingServiceName,
+		Endpoint:    cfg.TracingEndpoint,
+		Protocol:    cfg.TracingProtocol,
+		Insecure:    cfg.TracingInsecure,
+		File:        cfg.TracingFile,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	srv.OnShutdown("tracing", shutdownTracing)
+
+	// Initialize Firebase
 	firebaseApp, err := firebase.InitializeApp(ctx)
 	if err != nil {
 		log.Fatalf("Failed to initialize Firebase: %v", err)
 	}
 
 	// Initialize repositories
-	userRepo := memory.NewUserRepository()
+	repos, err := newRepositories(ctx, cfg, firebaseApp)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Instrument the repositories and expose the overdue tools gauge
+	appMetrics := metrics.New()
+	repos.instrument(appMetrics, cfg.StorageBackend)
+	appMetrics.RegisterOverdueTools(func(ctx context.Context) (int, error) {
+		overdue, err := repos.rentals.FindOverdue(ctx, time.Now())
+		return len(overdue), err
+	})
+
+	if repos.close != nil {
+		srv.OnShutdown("storage", func(context.Context) error {
+			return repos.close()
+		})
+	}
+
+	// Register the dependency checks behind the readiness endpoint
+	checks := health.NewRegistry(cfg.HealthCheckTimeout)
+	checks.Register("server", true, func(context.Context) error {
+		if !srv.Ready() {
+			return errors.New("server is shutting down")
+		}
+		return nil
+	})
+	if repos.ping != nil {
+		checks.Register("storage", true, repos.ping)
+	}
 
 	// Initialize domain services
-	var authService *firebase.AuthService
-	if firebaseApp != nil {
-		authService = firebase.NewAuthService(firebaseApp)
-	} else {
-		log.Println("WARNING: Firebase not initialized. Authentication will not work.")
+	authProvider, err := newAuthProvider(ctx, cfg, firebaseApp, repos, appMetrics)
+	if err != nil {
+		log.Fatalf("Failed to initialize authentication: %v", err)
+	}
+
+	if authProvider.close != nil {
+		srv.OnShutdown("auth", authProvider.close)
+	}
+	if authProvider.check != nil {
+		checks.Register("auth", true, authProvider.check)
+	}
+	if authProvider.mailCheck != nil {
+		checks.Register("mail", false, authProvider.mailCheck)
 	}
 
 	// Initialize application use cases
-	authUseCase := authApp.NewUseCase(authService, userRepo)
-	userUseCase := userApp.NewUseCase(userRepo)
+	authUseCase := authApp.NewUseCase(authProvider.service, authProvider.roles, repos.users, cfg.AdminUserIDs, cfg.RequireMemberApproval)
+	userUseCase := userApp.NewUseCase(repos.users, repos.rentals, repos.tools, repos.credentials, authProvider.deleter, authUseCase)
+	toolUseCase := toolApp.NewUseCase(repos.tools, repos.toolBlocks, repos.rentals)
+	rentalUseCase := rentalApp.NewUseCase(repos.rentals, repos.tools, repos.users)
 
 	// Initialize HTTP handlers
-	healthHandler := handlers.NewHealthHandler()
+	healthHandler := handlers.NewHealthHandler(checks)
 	authHandler := handlers.NewAuthHandler(authUseCase)
 	userHandler := handlers.NewUserHandler(userUseCase)
+	toolHandler := handlers.NewToolHandler(toolUseCase)
+	rentalHandler := handlers.NewRentalHandler(rentalUseCase)
+	adminHandler := handlers.NewAdminHandler(userUseCase)
+
+	var accountHandler *handlers.AccountHandler
+	if authProvider.accounts != nil {
+		accountHandler = handlers.NewAccountHandler(authProvider.accounts, authProvider.keys)
+	}
 
 	// Setup router with all routes
 	router := routes.NewRouter(
 		healthHandler,
 		authHandler,
 		userHandler,
-		authUseCase,
-		authService != nil,
+		toolHandler,
+		rentalHandler,
+		adminHandler,
+		accountHandler,
+		newAuthMiddleware(cfg, authUseCase, appMetrics),
+		newRevocationCheck(cfg, authUseCase, appMetrics),
+		logger,
+		trustedProxies,
+		appMetrics,
+		corsPolicy,
+		routes.RateLimits{
+			Store:  middleware.NewMemoryRateLimitStore(),
+			Auth:   middleware.RateLimit(cfg.RateLimitAuth),
+			Public: middleware.RateLimit(cfg.RateLimitPublic),
+			User:   middleware.RateLimit(cfg.RateLimitUser),
+			UserIP: middleware.RateLimit(cfg.RateLimitUserIP),
+		},
 	)
 	r := router.Setup()
 
-	// Start server
-	serverCfg := server.Config{
-		Port:         cfg.Port,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+	// Serve until SIGINT or SIGTERM, then drain and run the shutdown hooks
+	if err := srv.Run(r); err != nil {
+		log.Fatal(err)
 	}
-
-	log.Fatal(server.Start(r, serverCfg))
+	log.Println("Server stopped")
 }
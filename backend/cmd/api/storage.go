@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	firebase "firebase.google.com/go/v4"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	firestoreRepo "github.com/yourusername/toolrentalclub/infrastructure/repository/firestore"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/instrumented"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/memory"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/postgres"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/sqlite"
+	"github.com/yourusername/toolrentalclub/pkg/config"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// repositories holds the storage backend selected by the configuration
+type repositories struct {
+	users      user.Repository
+	tools      tool.Repository
+	toolBlocks tool.BlockRepository
+	rentals    rental.Repository
+
+	// credentials are the passwords of the self-hosted auth provider
+	credentials auth.CredentialRepository
+
+	// ping checks that the database can be reached and close releases the
+	// connection or client; both are nil for in-memory storage
+	ping  func(ctx context.Context) error
+	close func() error
+}
+
+// newRepositories creates the repositories for the configured storage backend.
+// The Firestore backend shares the Firebase app used for authentication.
+func newRepositories(ctx context.Context, cfg *config.Config, firebaseApp *firebase.App) (*repositories, error) {
+	switch cfg.StorageBackend {
+	case "memory":
+		log.Println("WARNING: Using in-memory storage; data will be lost on restart.")
+		toolBlocks := memory.NewToolBlockRepository()
+		return &repositories{
+			users:       memory.NewUserRepository(),
+			tools:       memory.NewToolRepository(),
+			toolBlocks:  toolBlocks,
+			rentals:     memory.NewRentalRepository(toolBlocks),
+			credentials: memory.NewCredentialRepository(),
+		}, nil
+
+	case "postgres":
+		if cfg.DatabaseURL == "" {
+			return nil, fmt.Errorf("STORAGE_BACKEND=postgres requires DATABASE_URL")
+		}
+		db, err := postgres.Open(ctx, cfg.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		log.Println("Connected to PostgreSQL and applied migrations")
+		return postgresRepositories(db), nil
+
+	case "sqlite":
+		db, err := sqlite.Open(ctx, cfg.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Opened SQLite database %s and applied migrations", cfg.SQLitePath)
+		return sqliteRepositories(db), nil
+
+	case "firestore":
+		client, err := firestoreRepo.NewClient(ctx, firebaseApp)
+		if err != nil {
+			return nil, err
+		}
+		log.Println("Using Firestore storage")
+		return &repositories{
+			users:       firestoreRepo.NewUserRepository(client),
+			tools:       firestoreRepo.NewToolRepository(client),
+			toolBlocks:  firestoreRepo.NewToolBlockRepository(client),
+			rentals:     firestoreRepo.NewRentalRepository(client),
+			credentials: firestoreRepo.NewCredentialRepository(client),
+			ping: func(ctx context.Context) error {
+				return firestoreRepo.Ping(ctx, client)
+			},
+			close: client.Close,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown STORAGE_BACKEND %q (expected memory, postgres, sqlite or firestore)", cfg.StorageBackend)
+}
+
+// instrument wraps every repository to record operation latencies and trace
+// each operation; backend names the storage backend in spans
+func (r *repositories) instrument(m *metrics.Metrics, backend string) {
+	r.users = instrumented.NewUserRepository(r.users, m, backend)
+	r.tools = instrumented.NewToolRepository(r.tools, m, backend)
+	r.toolBlocks = instrumented.NewToolBlockRepository(r.toolBlocks, m, backend)
+	r.rentals = instrumented.NewRentalRepository(r.rentals, m, backend)
+	r.credentials = instrumented.NewCredentialRepository(r.credentials, m, backend)
+}
+
+func postgresRepositories(db *sql.DB) *repositories {
+	return &repositories{
+		users:       postgres.NewUserRepository(db),
+		tools:       postgres.NewToolRepository(db),
+		toolBlocks:  postgres.NewToolBlockRepository(db),
+		rentals:     postgres.NewRentalRepository(db),
+		credentials: postgres.NewCredentialRepository(db),
+		ping:        db.PingContext,
+		close:       db.Close,
+	}
+}
+
+func sqliteRepositories(db *sql.DB) *repositories {
+	return &repositories{
+		users:       sqlite.NewUserRepository(db),
+		tools:       sqlite.NewToolRepository(db),
+		toolBlocks:  sqlite.NewToolBlockRepository(db),
+		rentals:     sqlite.NewRentalRepository(db),
+		credentials: sqlite.NewCredentialRepository(db),
+		ping:        db.PingContext,
+		close:       db.Close,
+	}
+}
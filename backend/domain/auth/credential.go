@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Credential holds the password login details of a user authenticated by
+// the self-hosted provider rather than by an external identity provider
+type Credential struct {
+	UserID       string
+	Email        string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewCredential creates a new Credential
+func NewCredential(userID, email, passwordHash string) *Credential {
+	now := time.Now()
+	return &Credential{
+		UserID:       userID,
+		Email:        email,
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// CredentialRepository defines the interface for credential data operations
+type CredentialRepository interface {
+	// FindByUserID retrieves the credential of a user
+	FindByUserID(ctx context.Context, userID string) (*Credential, error)
+
+	// FindByEmail retrieves a credential by login email
+	FindByEmail(ctx context.Context, email string) (*Credential, error)
+
+	// Create creates a new credential
+	Create(ctx context.Context, credential *Credential) error
+
+	// Update updates an existing credential
+	Update(ctx context.Context, credential *Credential) error
+
+	// Delete removes the credential of a user
+	Delete(ctx context.Context, userID string) error
+}
+
+// PasswordHasher hashes and verifies passwords
+type PasswordHasher interface {
+	// Hash returns a salted hash of the password
+	Hash(password string) (string, error)
+
+	// Compare returns ErrInvalidCredentials if password does not match hash
+	Compare(hash, password string) error
+}
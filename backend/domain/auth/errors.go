@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+var (
+	// ErrInvalidCredentials is returned when an email/password pair does not match
+	ErrInvalidCredentials = errs.Unauthenticated("invalid_credentials", "invalid email or password")
+
+	// ErrCredentialNotFound is returned when a user has no password credential
+	ErrCredentialNotFound = errs.NotFound("credential_not_found", "credential not found")
+
+	// ErrEmailTaken is returned when registering an email that is already in use
+	ErrEmailTaken = user.ErrEmailTaken
+
+	// ErrWeakPassword is returned when a password does not meet the password policy
+	ErrWeakPassword = errs.Validation("weak_password", "password does not meet requirements")
+
+	// ErrInvalidEmail is returned when an email address is malformed
+	ErrInvalidEmail = errs.Validation("invalid_email", "invalid email address")
+
+	// ErrUnauthenticated is returned when an operation requires an authenticated principal
+	ErrUnauthenticated = errs.Unauthenticated("unauthenticated", "authentication required")
+
+	// ErrInvalidToken is returned when a token is malformed, expired or not signed by the provider
+	ErrInvalidToken = errs.Unauthenticated("invalid_token", "invalid or expired token")
+
+	// ErrTokenRevoked is returned when a token has been revoked or its user disabled
+	ErrTokenRevoked = errs.Unauthenticated("token_revoked", "token has been revoked")
+
+	// ErrProviderUnavailable is returned when no authentication provider is configured
+	// or the provider cannot be reached
+	ErrProviderUnavailable = errs.Unavailable("provider_unavailable", "authentication provider not available")
+
+	// ErrInvalidResetToken is returned when a password reset token is invalid, expired or used
+	ErrInvalidResetToken = errs.Validation("invalid_reset_token", "invalid or expired password reset token")
+)
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// IssuedToken is a signed token handed out to a client
+type IssuedToken struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// TokenIssuer issues and verifies the tokens of the self-hosted provider
+type TokenIssuer interface {
+	// IssueAccessToken issues a token that authenticates the user on API requests
+	IssueAccessToken(ctx context.Context, userID, email string) (*IssuedToken, error)
+
+	// IssueResetToken issues a short-lived password reset token. The
+	// fingerprint binds the token to the current password so that it stops
+	// working once the password has been changed.
+	IssueResetToken(ctx context.Context, userID, fingerprint string) (*IssuedToken, error)
+
+	// VerifyResetToken validates a password reset token and returns the user
+	// ID and password fingerprint it was issued for
+	VerifyResetToken(ctx context.Context, token string) (userID, fingerprint string, err error)
+}
+
+// JSONWebKey is a public signing key in JSON Web Key (RFC 7517) form
+type JSONWebKey struct {
+	KeyType   string
+	KeyID     string
+	Algorithm string
+	Use       string
+	Curve     string
+	Modulus   string
+	Exponent  string
+	X         string
+}
+
+// KeyPublisher exposes the public keys that verify issued tokens
+type KeyPublisher interface {
+	// PublicKeys returns every key that may have signed a still-valid token
+	PublicKeys() []JSONWebKey
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// Principal is the authenticated identity a request is made by
+type Principal struct {
+	UserID string
+
+	// Email is the user's stored email, which is the token's unless that
+	// address belongs to another account
+	Email         string
+	EmailVerified bool
+
+	// Roles are the user's effective roles, excluding the implicit member role
+	Roles []user.Role
+
+	// Provider names the identity provider that authenticated the user,
+	// e.g. "firebase", "jwt" or "dev-insecure"
+	Provider string
+
+	// ExpiresAt is when the credential behind the request stops being valid
+	ExpiresAt time.Time
+
+	// AuthTime is when the user last signed in with their credentials
+	AuthTime time.Time
+}
+
+// Can reports whether the principal's roles grant the permission
+func (p *Principal) Can(permission user.Permission) bool {
+	return user.HasPermission(p.Roles, permission)
+}
+
+// principalKey is the context key of the Principal. Being unexported, it
+// cannot collide with keys set by other packages.
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// RequirePrincipal returns the principal carried by ctx, or
+// ErrUnauthenticated if the request is anonymous
+func RequirePrincipal(ctx context.Context) (*Principal, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	return p, nil
+}
@@ -8,3 +8,27 @@ type Service interface {
 	VerifyToken(ctx context.Context, token string) (*Token, error)
 }
 
+// AccountDeleter is implemented by identity providers that hold an account
+// the user signs in with, so that leaving the club can also delete it
+type AccountDeleter interface {
+	// DeleteAccount deletes the user's account with the provider. Deleting
+	// an account that does not exist succeeds.
+	DeleteAccount(ctx context.Context, userID string) error
+}
+
+// RevocationChecker is implemented by services that can also reject tokens
+// revoked since they were issued. Checking revocation costs a round trip to
+// the identity provider, so it is reserved for sensitive operations.
+type RevocationChecker interface {
+	// VerifyTokenAndCheckRevoked verifies a token like VerifyToken and
+	// returns ErrTokenRevoked if it has been revoked or the user disabled
+	VerifyTokenAndCheckRevoked(ctx context.Context, token string) (*Token, error)
+}
+
+// RoleLookup is implemented by identity providers that grant roles, so that
+// the roles of a user other than the principal can be resolved
+type RoleLookup interface {
+	// LookupRoles returns the roles the provider grants the user; a user the
+	// provider does not know has none
+	LookupRoles(ctx context.Context, userID string) ([]string, error)
+}
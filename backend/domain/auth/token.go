@@ -1,10 +1,31 @@
 package auth
 
+import "time"
+
 // Token represents an authentication token in the domain
 type Token struct {
 	Value  string
 	UserID string
 	Email  string
+
+	// EmailVerified, Name and Picture are the identity provider's claims
+	// about the user; Picture is the URL of their profile picture
+	EmailVerified bool
+	Name          string
+	Picture       string
+
+	// Roles are role names asserted by the identity provider, such as
+	// Firebase custom claims; they add to the roles stored for the user
+	Roles []string
+
+	// Provider names the identity provider that issued the token
+	Provider string
+
+	// ExpiresAt is when the token stops being valid
+	ExpiresAt time.Time
+
+	// AuthTime is when the user last signed in with their credentials
+	AuthTime time.Time
 }
 
 // NewToken creates a new Token
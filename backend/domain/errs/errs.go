@@ -0,0 +1,130 @@
+// Package errs defines the error model shared by the domain packages. Every
+// domain error has a Kind, which says what went wrong in terms any interface
+// can map to a response, and a stable Code that clients may rely on.
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind classifies a domain error
+type Kind string
+
+const (
+	// KindInternal is the kind of every error that is not a domain error
+	KindInternal Kind = "internal"
+
+	// KindNotFound means the requested entity does not exist
+	KindNotFound Kind = "not_found"
+
+	// KindConflict means the operation clashes with the current state
+	KindConflict Kind = "conflict"
+
+	// KindValidation means the input is invalid; Fields may say which parts
+	KindValidation Kind = "validation"
+
+	// KindForbidden means the actor may not perform the operation
+	KindForbidden Kind = "forbidden"
+
+	// KindUnauthenticated means the operation requires a valid identity
+	KindUnauthenticated Kind = "unauthenticated"
+
+	// KindUnavailable means a dependency needed for the operation is unavailable
+	KindUnavailable Kind = "unavailable"
+)
+
+// FieldError describes why one input field is invalid
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error is a domain error. Errors are declared once as package-level
+// sentinels and compared with errors.Is, which matches on Code so that
+// copies made by WithField still match their sentinel.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// New creates a domain error
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// NotFound creates an error of KindNotFound
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict creates an error of KindConflict
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Validation creates an error of KindValidation
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Forbidden creates an error of KindForbidden
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// Unauthenticated creates an error of KindUnauthenticated
+func Unauthenticated(code, message string) *Error {
+	return New(KindUnauthenticated, code, message)
+}
+
+// Unavailable creates an error of KindUnavailable
+func Unavailable(code, message string) *Error {
+	return New(KindUnavailable, code, message)
+}
+
+// Error returns the message followed by the field errors, if any
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	details := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		details[i] = f.Field + " " + f.Message
+	}
+	return e.Message + ": " + strings.Join(details, ", ")
+}
+
+// Is reports whether target is a domain error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithField returns a copy of the error that also reports field as invalid
+func (e *Error) WithField(field, message string) *Error {
+	c := *e
+	c.Fields = append(append([]FieldError(nil), e.Fields...), FieldError{Field: field, Message: message})
+	return &c
+}
+
+// As returns the domain error in err's chain, if there is one
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// KindOf returns the kind of the domain error in err's chain, or
+// KindInternal if there is none
+func KindOf(err error) Kind {
+	if e, ok := As(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
@@ -0,0 +1,16 @@
+package mail
+
+import "context"
+
+// Message is an email to deliver to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender defines the interface for delivering email
+type Sender interface {
+	// Send delivers a message
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,149 @@
+package rental
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// Status describes where a reservation is in its lifecycle
+type Status string
+
+const (
+	StatusRequested Status = "requested"
+	StatusConfirmed Status = "confirmed"
+	StatusPickedUp  Status = "picked_up"
+	StatusReturned  Status = "returned"
+	StatusCancelled Status = "cancelled"
+)
+
+// transitions lists the states each state may move to
+var transitions = map[Status][]Status{
+	StatusRequested: {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusPickedUp, StatusCancelled},
+	StatusPickedUp:  {StatusReturned},
+}
+
+// Blocking reports whether a reservation in this state holds the tool,
+// preventing overlapping reservations from being created or confirmed
+func (s Status) Blocking() bool {
+	return s == StatusConfirmed || s == StatusPickedUp
+}
+
+// CanTransitionTo reports whether a reservation may move from s to next
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Rental represents the reservation of a tool by a user for a date range.
+// The range is half-open: the tool is held from Start up to, but not including, End.
+type Rental struct {
+	ID        string
+	ToolID    string
+	RenterID  string
+	Start     time.Time
+	End       time.Time
+	Status    Status
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewRental creates a new requested Rental
+func NewRental(id, toolID, renterID string, start, end time.Time) *Rental {
+	now := time.Now()
+	return &Rental{
+		ID:        id,
+		ToolID:    toolID,
+		RenterID:  renterID,
+		Start:     start,
+		End:       end,
+		Status:    StatusRequested,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the rental's invariants
+func (r *Rental) Validate() error {
+	if r.ToolID == "" {
+		return ErrInvalidRental.WithField("toolId", "is required")
+	}
+	if r.RenterID == "" {
+		return ErrInvalidRental.WithField("renterId", "is required")
+	}
+	if r.Start.IsZero() {
+		return ErrInvalidRental.WithField("start", "is required")
+	}
+	if r.End.IsZero() {
+		return ErrInvalidRental.WithField("end", "is required")
+	}
+	if !r.End.After(r.Start) {
+		return ErrInvalidRental.WithField("end", "must be after start")
+	}
+	return nil
+}
+
+// Overlaps reports whether the rental's date range intersects [start, end)
+func (r *Rental) Overlaps(start, end time.Time) bool {
+	return r.Start.Before(end) && start.Before(r.End)
+}
+
+// ConflictsWith reports whether r and other cannot both hold the same tool
+func (r *Rental) ConflictsWith(other *Rental) bool {
+	return r.ID != other.ID &&
+		r.ToolID == other.ToolID &&
+		other.Status.Blocking() &&
+		r.Overlaps(other.Start, other.End)
+}
+
+// CheckConflicts returns ErrConflict if r cannot be stored next to the existing
+// rentals of its tool. New reservations may not overlap any blocking rental;
+// existing reservations are only checked once they become blocking themselves.
+func (r *Rental) CheckConflicts(existing []*Rental, isNew bool) error {
+	if !isNew && !r.Status.Blocking() {
+		return nil
+	}
+	for _, other := range existing {
+		if r.ConflictsWith(other) {
+			return ErrConflict
+		}
+	}
+	return nil
+}
+
+// CheckBlocks returns ErrToolBlocked if r overlaps a maintenance or blackout
+// block of its tool. Blocks keep reservations from being made and confirmed,
+// but a rental confirmed before its tool was blocked can still be picked up
+// and returned; previous is the status r is stored with, empty for a new rental.
+func (r *Rental) CheckBlocks(blocks []*tool.Block, previous Status) error {
+	if previous != "" && (previous.Blocking() || !r.Status.Blocking()) {
+		return nil
+	}
+	for _, b := range blocks {
+		if b.ToolID == r.ToolID && b.Overlaps(r.Start, r.End) {
+			return ErrToolBlocked
+		}
+	}
+	return nil
+}
+
+// IsOverdue reports whether the tool has been picked up and not returned by the end date
+func (r *Rental) IsOverdue(now time.Time) bool {
+	return r.Status == StatusPickedUp && !now.Before(r.End)
+}
+
+// TransitionTo moves the rental to the next state if the lifecycle allows it
+func (r *Rental) TransitionTo(next Status) error {
+	if !r.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: cannot move from %s to %s", ErrInvalidTransition, r.Status, next)
+	}
+	r.Status = next
+	r.UpdatedAt = time.Now()
+	return nil
+}
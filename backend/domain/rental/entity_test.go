@@ -0,0 +1,279 @@
+package rental
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// day returns midnight UTC of the given day in January 2030
+func day(d int) time.Time {
+	return time.Date(2030, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRentalOverlaps(t *testing.T) {
+	r := &Rental{Start: day(10), End: day(15)}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       bool
+	}{
+		{"same range", day(10), day(15), true},
+		{"inside", day(11), day(12), true},
+		{"around", day(5), day(20), true},
+		{"overlaps start", day(8), day(11), true},
+		{"overlaps end", day(14), day(18), true},
+		{"ends at start", day(5), day(10), false},
+		{"starts at end", day(15), day(18), false},
+		{"before", day(1), day(5), false},
+		{"after", day(20), day(25), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Overlaps(tt.start, tt.end); got != tt.want {
+				t.Errorf("Overlaps(%s, %s) = %t, want %t", tt.start.Format(time.DateOnly), tt.end.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalCheckConflicts(t *testing.T) {
+	existing := func(id, toolID string, status Status, start, end time.Time) *Rental {
+		r := NewRental(id, toolID, "other-renter", start, end)
+		r.Status = status
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		rental   *Rental
+		existing []*Rental
+		isNew    bool
+		want     error
+	}{
+		{
+			name:   "no rentals",
+			rental: NewRental("new", "drill", "renter", day(10), day(15)),
+			isNew:  true,
+			want:   nil,
+		},
+		{
+			name:     "new overlapping confirmed",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusConfirmed, day(12), day(20))},
+			isNew:    true,
+			want:     ErrConflict,
+		},
+		{
+			name:     "new overlapping picked up",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusPickedUp, day(1), day(11))},
+			isNew:    true,
+			want:     ErrConflict,
+		},
+		{
+			name:     "new overlapping requested",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusRequested, day(10), day(15))},
+			isNew:    true,
+			want:     nil,
+		},
+		{
+			name:     "new overlapping cancelled",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusCancelled, day(10), day(15))},
+			isNew:    true,
+			want:     nil,
+		},
+		{
+			name:     "new adjacent to confirmed",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusConfirmed, day(15), day(20))},
+			isNew:    true,
+			want:     nil,
+		},
+		{
+			name:     "new overlapping confirmed of another tool",
+			rental:   NewRental("new", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "saw", StatusConfirmed, day(10), day(15))},
+			isNew:    true,
+			want:     nil,
+		},
+		{
+			name:     "requested update is not checked",
+			rental:   NewRental("r2", "drill", "renter", day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusConfirmed, day(10), day(15))},
+			isNew:    false,
+			want:     nil,
+		},
+		{
+			name:     "confirming overlapping confirmed",
+			rental:   existing("r2", "drill", StatusConfirmed, day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusConfirmed, day(14), day(16))},
+			isNew:    false,
+			want:     ErrConflict,
+		},
+		{
+			name:     "confirmed rental does not conflict with itself",
+			rental:   existing("r1", "drill", StatusConfirmed, day(10), day(15)),
+			existing: []*Rental{existing("r1", "drill", StatusConfirmed, day(10), day(15))},
+			isNew:    false,
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rental.CheckConflicts(tt.existing, tt.isNew); !errors.Is(err, tt.want) {
+				t.Errorf("CheckConflicts() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalCheckBlocks(t *testing.T) {
+	blocks := []*tool.Block{
+		tool.NewBlock("b1", "drill", tool.BlockMaintenance, day(12), day(14), "new battery"),
+		tool.NewBlock("b2", "saw", tool.BlockBlackout, day(1), day(30), "on holiday"),
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		status   Status
+		previous Status
+		want     error
+	}{
+		{"new over a block", day(10), day(13), StatusRequested, "", ErrToolBlocked},
+		{"new next to a block", day(10), day(12), StatusRequested, "", nil},
+		{"new over another tool's block", day(15), day(20), StatusRequested, "", nil},
+		{"confirming over a block", day(13), day(15), StatusConfirmed, StatusRequested, ErrToolBlocked},
+		{"cancelling over a block", day(13), day(15), StatusCancelled, StatusRequested, nil},
+		{"picking up over a later block", day(13), day(15), StatusPickedUp, StatusConfirmed, nil},
+		{"returning over a later block", day(13), day(15), StatusReturned, StatusPickedUp, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRental("r1", "drill", "renter", tt.start, tt.end)
+			r.Status = tt.status
+
+			if err := r.CheckBlocks(blocks, tt.previous); !errors.Is(err, tt.want) {
+				t.Errorf("CheckBlocks() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		allowed  bool
+	}{
+		{StatusRequested, StatusConfirmed, true},
+		{StatusRequested, StatusCancelled, true},
+		{StatusRequested, StatusPickedUp, false},
+		{StatusRequested, StatusReturned, false},
+		{StatusConfirmed, StatusPickedUp, true},
+		{StatusConfirmed, StatusCancelled, true},
+		{StatusConfirmed, StatusRequested, false},
+		{StatusConfirmed, StatusReturned, false},
+		{StatusPickedUp, StatusReturned, true},
+		{StatusPickedUp, StatusCancelled, false},
+		{StatusReturned, StatusPickedUp, false},
+		{StatusReturned, StatusCancelled, false},
+		{StatusCancelled, StatusRequested, false},
+		{StatusCancelled, StatusConfirmed, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			r := NewRental("r1", "drill", "renter", day(10), day(15))
+			r.Status = tt.from
+
+			err := r.TransitionTo(tt.to)
+			switch {
+			case tt.allowed && err != nil:
+				t.Fatalf("TransitionTo() = %v, want nil", err)
+			case tt.allowed && r.Status != tt.to:
+				t.Errorf("Status = %s, want %s", r.Status, tt.to)
+			case !tt.allowed && !errors.Is(err, ErrInvalidTransition):
+				t.Fatalf("TransitionTo() = %v, want ErrInvalidTransition", err)
+			case !tt.allowed && r.Status != tt.from:
+				t.Errorf("Status = %s after a refused transition, want %s", r.Status, tt.from)
+			}
+		})
+	}
+}
+
+func TestRentalValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rental *Rental
+		field  string
+	}{
+		{"valid", NewRental("r1", "drill", "renter", day(10), day(11)), ""},
+		{"missing tool", NewRental("r1", "", "renter", day(10), day(11)), "toolId"},
+		{"missing renter", NewRental("r1", "drill", "", day(10), day(11)), "renterId"},
+		{"missing start", NewRental("r1", "drill", "renter", time.Time{}, day(11)), "start"},
+		{"missing end", NewRental("r1", "drill", "renter", day(10), time.Time{}), "end"},
+		{"empty range", NewRental("r1", "drill", "renter", day(10), day(10)), "end"},
+		{"reversed range", NewRental("r1", "drill", "renter", day(11), day(10)), "end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rental.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidRental) {
+				t.Fatalf("Validate() = %v, want ErrInvalidRental", err)
+			}
+			if fields := fieldsOf(err); len(fields) != 1 || fields[0] != tt.field {
+				t.Errorf("invalid fields = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+}
+
+func TestRentalIsOverdue(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		now    time.Time
+		want   bool
+	}{
+		{"picked up before end", StatusPickedUp, day(14), false},
+		{"picked up at end", StatusPickedUp, day(15), true},
+		{"picked up after end", StatusPickedUp, day(20), true},
+		{"returned after end", StatusReturned, day(20), false},
+		{"confirmed after end", StatusConfirmed, day(20), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRental("r1", "drill", "renter", day(10), day(15))
+			r.Status = tt.status
+			if got := r.IsOverdue(tt.now); got != tt.want {
+				t.Errorf("IsOverdue() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+// fieldsOf returns the names of the invalid fields a validation error reports
+func fieldsOf(err error) []string {
+	domainErr, ok := errs.As(err)
+	if !ok {
+		return nil
+	}
+	fields := make([]string, len(domainErr.Fields))
+	for i, f := range domainErr.Fields {
+		fields[i] = f.Field
+	}
+	return fields
+}
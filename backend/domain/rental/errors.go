@@ -0,0 +1,33 @@
+package rental
+
+import "github.com/yourusername/toolrentalclub/domain/errs"
+
+var (
+	// ErrRentalNotFound is returned when a rental does not exist
+	ErrRentalNotFound = errs.NotFound("rental_not_found", "rental not found")
+
+	// ErrRentalExists is returned when creating a rental whose ID is already taken
+	ErrRentalExists = errs.Conflict("rental_exists", "rental already exists")
+
+	// ErrInvalidRental is returned when a rental violates its invariants
+	ErrInvalidRental = errs.Validation("invalid_rental", "invalid rental")
+
+	// ErrInvalidTransition is returned when a state change is not allowed
+	ErrInvalidTransition = errs.Conflict("invalid_transition", "invalid rental state transition")
+
+	// ErrConflict is returned when a rental overlaps a confirmed reservation of the same tool
+	ErrConflict = errs.Conflict("rental_conflict", "tool is already reserved for the requested dates")
+
+	// ErrToolBlocked is returned when a rental overlaps a maintenance or blackout block
+	ErrToolBlocked = errs.Conflict("tool_blocked", "tool is blocked for maintenance or by its owner during the requested dates")
+
+	// ErrForbidden is returned when a user may not view or change a rental
+	ErrForbidden = errs.Forbidden("rental_forbidden", "not allowed to access this rental")
+
+	// ErrRenterInactive is returned when confirming or handing over a rental
+	// whose renter is pending approval, suspended or closed
+	ErrRenterInactive = errs.Conflict("renter_inactive", "renter account is not active")
+
+	// ErrToolUnavailable is returned when the tool cannot currently be rented
+	ErrToolUnavailable = errs.Conflict("tool_unavailable", "tool is not available for rental")
+)
@@ -0,0 +1,40 @@
+package rental
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for rental data operations.
+//
+// Implementations must enforce the booking invariant (see Rental.CheckConflicts)
+// atomically with the write, so that concurrent requests can never leave two
+// overlapping blocking (confirmed or picked up) rentals for the same tool.
+// The tool's maintenance and blackout blocks (see Rental.CheckBlocks) are
+// checked in the same transaction, and tool.BlockRepository.Create takes the
+// same per-tool lock, so a block cannot slip in while a reservation is stored.
+type Repository interface {
+	// FindByID retrieves a rental by its ID
+	FindByID(ctx context.Context, id string) (*Rental, error)
+
+	// FindByToolID retrieves every rental of a tool
+	FindByToolID(ctx context.Context, toolID string) ([]*Rental, error)
+
+	// FindByRenterID retrieves every rental made by a user
+	FindByRenterID(ctx context.Context, renterID string) ([]*Rental, error)
+
+	// FindOverdue retrieves every rental that is overdue at now (see Rental.IsOverdue)
+	FindOverdue(ctx context.Context, now time.Time) ([]*Rental, error)
+
+	// Create creates a new rental, rejecting it if it conflicts with a blocking
+	// rental or a block of its tool
+	Create(ctx context.Context, rental *Rental) error
+
+	// Update updates an existing rental, rejecting it if it conflicts with a
+	// blocking rental or, when it is being confirmed, a block of its tool.
+	// The write only applies if the stored rental still has the expected
+	// status, the one it had when it was loaded, so that concurrent
+	// transitions cannot overwrite each other; otherwise it returns
+	// ErrInvalidTransition.
+	Update(ctx context.Context, rental *Rental, expected Status) error
+}
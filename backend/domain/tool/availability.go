@@ -0,0 +1,104 @@
+package tool
+
+import (
+	"sort"
+	"time"
+)
+
+// BusyReason explains why a tool is unavailable during an interval
+type BusyReason string
+
+const (
+	ReasonReservation BusyReason = "reservation"
+	ReasonMaintenance BusyReason = "maintenance"
+	ReasonBlackout    BusyReason = "blackout"
+)
+
+// Interval is a half-open period [Start, End) in a tool's availability calendar
+type Interval struct {
+	Start   time.Time
+	End     time.Time
+	Busy    bool
+	Reasons []BusyReason
+}
+
+// BusyInterval creates a busy interval with a single reason
+func BusyInterval(start, end time.Time, reason BusyReason) Interval {
+	return Interval{Start: start, End: end, Busy: true, Reasons: []BusyReason{reason}}
+}
+
+// Availability splits the window [from, to) into alternating free and busy
+// intervals. Busy intervals are clipped to the window and merged when they
+// overlap or touch, combining their reasons. The result is ordered by start
+// and covers the whole window without gaps.
+func Availability(from, to time.Time, busy []Interval) []Interval {
+	if !to.After(from) {
+		return []Interval{}
+	}
+
+	clipped := make([]Interval, 0, len(busy))
+	for _, b := range busy {
+		if b.Start.Before(from) {
+			b.Start = from
+		}
+		if b.End.After(to) {
+			b.End = to
+		}
+		if b.End.After(b.Start) {
+			clipped = append(clipped, b)
+		}
+	}
+
+	sort.Slice(clipped, func(i, j int) bool {
+		return clipped[i].Start.Before(clipped[j].Start)
+	})
+
+	merged := make([]Interval, 0, len(clipped))
+	for _, b := range clipped {
+		if n := len(merged); n > 0 && !b.Start.After(merged[n-1].End) {
+			last := &merged[n-1]
+			if b.End.After(last.End) {
+				last.End = b.End
+			}
+			last.Reasons = mergeReasons(last.Reasons, b.Reasons)
+			continue
+		}
+		merged = append(merged, Interval{
+			Start:   b.Start,
+			End:     b.End,
+			Busy:    true,
+			Reasons: mergeReasons(nil, b.Reasons),
+		})
+	}
+
+	intervals := make([]Interval, 0, 2*len(merged)+1)
+	cursor := from
+	for _, b := range merged {
+		if b.Start.After(cursor) {
+			intervals = append(intervals, Interval{Start: cursor, End: b.Start})
+		}
+		intervals = append(intervals, b)
+		cursor = b.End
+	}
+	if to.After(cursor) {
+		intervals = append(intervals, Interval{Start: cursor, End: to})
+	}
+
+	return intervals
+}
+
+// mergeReasons returns the sorted union of two reason lists
+func mergeReasons(a, b []BusyReason) []BusyReason {
+	seen := make(map[BusyReason]bool, len(a)+len(b))
+	reasons := make([]BusyReason, 0, len(a)+len(b))
+	for _, list := range [][]BusyReason{a, b} {
+		for _, reason := range list {
+			if !seen[reason] {
+				seen[reason] = true
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+	sort.Slice(reasons, func(i, j int) bool { return reasons[i] < reasons[j] })
+	return reasons
+}
@@ -0,0 +1,67 @@
+package tool
+
+import (
+	"fmt"
+	"time"
+)
+
+// BlockKind describes why a tool is blocked from being rented
+type BlockKind string
+
+const (
+	BlockMaintenance BlockKind = "maintenance"
+	BlockBlackout    BlockKind = "blackout"
+)
+
+// Valid reports whether the block kind is one of the known values
+func (k BlockKind) Valid() bool {
+	return k == BlockMaintenance || k == BlockBlackout
+}
+
+// Block marks a period during which a tool cannot be rented, either because
+// it is in maintenance or because its owner has blacked out the dates.
+// The range is half-open: [Start, End).
+type Block struct {
+	ID        string
+	ToolID    string
+	Kind      BlockKind
+	Start     time.Time
+	End       time.Time
+	Reason    string
+	CreatedAt time.Time
+}
+
+// NewBlock creates a new Block
+func NewBlock(id, toolID string, kind BlockKind, start, end time.Time, reason string) *Block {
+	return &Block{
+		ID:        id,
+		ToolID:    toolID,
+		Kind:      kind,
+		Start:     start,
+		End:       end,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Validate checks the block's invariants
+func (b *Block) Validate() error {
+	if !b.Kind.Valid() {
+		return ErrInvalidBlock.WithField("kind", fmt.Sprintf("%q is unknown", b.Kind))
+	}
+	if b.Start.IsZero() {
+		return ErrInvalidBlock.WithField("start", "is required")
+	}
+	if b.End.IsZero() {
+		return ErrInvalidBlock.WithField("end", "is required")
+	}
+	if !b.End.After(b.Start) {
+		return ErrInvalidBlock.WithField("end", "must be after start")
+	}
+	return nil
+}
+
+// Overlaps reports whether the block intersects [start, end)
+func (b *Block) Overlaps(start, end time.Time) bool {
+	return b.Start.Before(end) && start.Before(b.End)
+}
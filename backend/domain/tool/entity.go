@@ -0,0 +1,105 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Category groups tools in the catalog (e.g. "power-tools", "garden")
+type Category string
+
+// Condition describes the physical state of a tool
+type Condition string
+
+const (
+	ConditionNew  Condition = "new"
+	ConditionGood Condition = "good"
+	ConditionFair Condition = "fair"
+	ConditionPoor Condition = "poor"
+)
+
+// Valid reports whether the condition is one of the known values
+func (c Condition) Valid() bool {
+	switch c {
+	case ConditionNew, ConditionGood, ConditionFair, ConditionPoor:
+		return true
+	}
+	return false
+}
+
+// Status describes whether a tool can currently be rented
+type Status string
+
+const (
+	StatusAvailable   Status = "available"
+	StatusUnavailable Status = "unavailable"
+	StatusRetired     Status = "retired"
+)
+
+// Valid reports whether the status is one of the known values
+func (s Status) Valid() bool {
+	switch s {
+	case StatusAvailable, StatusUnavailable, StatusRetired:
+		return true
+	}
+	return false
+}
+
+// Tool represents a tool in the club's rental catalog.
+// Monetary amounts are stored in the smallest currency unit (cents).
+type Tool struct {
+	ID                    string
+	Name                  string
+	Description           string
+	Category              Category
+	Condition             Condition
+	ReplacementValueCents int64
+	DailyRateCents        int64
+	OwnerID               string
+	Status                Status
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// NewTool creates a new available Tool owned by ownerID
+func NewTool(id, ownerID, name string) *Tool {
+	now := time.Now()
+	return &Tool{
+		ID:        id,
+		Name:      name,
+		OwnerID:   ownerID,
+		Condition: ConditionGood,
+		Status:    StatusAvailable,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate checks the tool's invariants
+func (t *Tool) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrInvalidTool.WithField("name", "is required")
+	}
+	if t.OwnerID == "" {
+		return ErrInvalidTool.WithField("ownerId", "is required")
+	}
+	if !t.Condition.Valid() {
+		return ErrInvalidTool.WithField("condition", fmt.Sprintf("%q is unknown", t.Condition))
+	}
+	if !t.Status.Valid() {
+		return ErrInvalidTool.WithField("status", fmt.Sprintf("%q is unknown", t.Status))
+	}
+	if t.ReplacementValueCents < 0 {
+		return ErrInvalidTool.WithField("replacementValueCents", "must not be negative")
+	}
+	if t.DailyRateCents < 0 {
+		return ErrInvalidTool.WithField("dailyRateCents", "must not be negative")
+	}
+	return nil
+}
+
+// IsOwnedBy reports whether the tool belongs to the given user
+func (t *Tool) IsOwnedBy(userID string) bool {
+	return t.OwnerID == userID
+}
@@ -0,0 +1,29 @@
+package tool
+
+import "github.com/yourusername/toolrentalclub/domain/errs"
+
+var (
+	// ErrToolNotFound is returned when a tool does not exist
+	ErrToolNotFound = errs.NotFound("tool_not_found", "tool not found")
+
+	// ErrToolExists is returned when creating a tool whose ID is already taken
+	ErrToolExists = errs.Conflict("tool_exists", "tool already exists")
+
+	// ErrInvalidTool is returned when a tool violates its invariants
+	ErrInvalidTool = errs.Validation("invalid_tool", "invalid tool")
+
+	// ErrBlockNotFound is returned when a maintenance or blackout block does not exist
+	ErrBlockNotFound = errs.NotFound("block_not_found", "block not found")
+
+	// ErrInvalidBlock is returned when a block violates its invariants
+	ErrInvalidBlock = errs.Validation("invalid_block", "invalid block")
+
+	// ErrActiveRentals is returned when removing a tool that has confirmed or picked-up rentals
+	ErrActiveRentals = errs.Conflict("tool_has_active_rentals", "tool has confirmed or picked-up rentals")
+
+	// ErrNotOwner is returned when a user tries to modify a tool they do not own
+	ErrNotOwner = errs.Forbidden("not_tool_owner", "only the tool owner can modify this tool")
+
+	// ErrCannotList is returned when a user without the tool owner role adds a tool
+	ErrCannotList = errs.Forbidden("cannot_list_tools", "only tool owners can add tools to the catalog")
+)
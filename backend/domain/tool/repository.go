@@ -0,0 +1,35 @@
+package tool
+
+import "context"
+
+// Repository defines the interface for tool data operations
+type Repository interface {
+	// FindByID retrieves a tool by its ID
+	FindByID(ctx context.Context, id string) (*Tool, error)
+
+	// FindAll retrieves every tool in the catalog
+	FindAll(ctx context.Context) ([]*Tool, error)
+
+	// Create creates a new tool
+	Create(ctx context.Context, tool *Tool) error
+
+	// Update updates an existing tool
+	Update(ctx context.Context, tool *Tool) error
+
+	// Delete removes a tool by its ID
+	Delete(ctx context.Context, id string) error
+}
+
+// BlockRepository defines the interface for tool block data operations
+type BlockRepository interface {
+	// FindByToolID retrieves every block of a tool
+	FindByToolID(ctx context.Context, toolID string) ([]*Block, error)
+
+	// Create creates a new block. It takes the per-tool lock that rental
+	// writes hold while they check for blocks, so it waits for reservations
+	// of the tool that are being stored.
+	Create(ctx context.Context, block *Block) error
+
+	// Delete removes a block by its ID
+	Delete(ctx context.Context, id string) error
+}
@@ -1,11 +1,29 @@
 package user
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// DeletedUserIDPrefix starts the pseudonym that replaces a deleted user's ID
+// in the records kept after they leave, such as past rentals
+const DeletedUserIDPrefix = "deleted-"
 
 // User represents the core user entity in the domain
 type User struct {
-	ID        string
-	Email     string
+	ID            string
+	Email         string
+	EmailVerified bool   // whether the identity provider has verified Email
+	Roles         []Role // roles granted on top of the implicit member role
+	Profile       Profile
+
+	// Status is whether the user may use the club. StatusReason explains a
+	// suspension or closure, and SuspendedUntil ends a suspension; it is zero
+	// for indefinite suspensions and every other status.
+	Status         Status
+	StatusReason   string
+	SuspendedUntil time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -16,8 +34,62 @@ func NewUser(id, email string) *User {
 	return &User{
 		ID:        id,
 		Email:     email,
+		Profile:   Profile{Notifications: DefaultNotificationPreferences()},
+		Status:    StatusActive,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
+// HasRole reports whether the user holds the role
+func (u *User) HasRole(role Role) bool {
+	if role == RoleMember {
+		return true
+	}
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantRole gives the user a role. Granting a role the user already holds is a no-op.
+func (u *User) GrantRole(role Role) error {
+	if !role.Valid() || role == RoleMember {
+		return ErrInvalidRole.WithField("role", fmt.Sprintf("%q cannot be granted", role))
+	}
+
+	u.Roles = MergeRoles(u.Roles, []Role{role})
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// RevokeRole takes a role away from the user. Revoking a role the user does not hold is a no-op.
+func (u *User) RevokeRole(role Role) error {
+	if !role.Valid() || role == RoleMember {
+		return ErrInvalidRole.WithField("role", fmt.Sprintf("%q cannot be revoked", role))
+	}
+
+	roles := make([]Role, 0, len(u.Roles))
+	for _, r := range u.Roles {
+		if r != role {
+			roles = append(roles, r)
+		}
+	}
+	u.Roles = roles
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateProfile replaces the user's profile after normalizing and validating it
+func (u *User) UpdateProfile(p Profile) error {
+	p.Normalize()
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	u.Profile = p
+	u.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,46 @@
+package user
+
+import "github.com/yourusername/toolrentalclub/domain/errs"
+
+var (
+	// ErrUserNotFound is returned when a user does not exist
+	ErrUserNotFound = errs.NotFound("user_not_found", "user not found")
+
+	// ErrUserExists is returned when creating a user whose ID is already taken
+	ErrUserExists = errs.Conflict("user_exists", "user already exists")
+
+	// ErrEmailTaken is returned when an email address already belongs to another user
+	ErrEmailTaken = errs.Conflict("email_taken", "email already registered")
+
+	// ErrInvalidRole is returned when granting or revoking an unknown role
+	ErrInvalidRole = errs.Validation("invalid_role", "invalid role")
+
+	// ErrInvalidProfile is returned when a profile field is malformed
+	ErrInvalidProfile = errs.Validation("invalid_profile", "invalid profile")
+
+	// ErrInvalidListQuery is returned when a user listing has a malformed filter, sort or cursor
+	ErrInvalidListQuery = errs.Validation("invalid_list_query", "invalid user list query")
+
+	// ErrAccountPending is returned when an account awaiting approval is used
+	ErrAccountPending = errs.Forbidden("account_pending", "account is awaiting approval")
+
+	// ErrAccountSuspended is returned when a suspended account is used
+	ErrAccountSuspended = errs.Forbidden("account_suspended", "account is suspended")
+
+	// ErrAccountClosed is returned when a closed account is used
+	ErrAccountClosed = errs.Forbidden("account_closed", "account is closed")
+
+	// ErrAccountDeleted is returned when a deleted account is used, for
+	// example with a token issued before it was deleted
+	ErrAccountDeleted = errs.Forbidden("account_deleted", "account has been deleted")
+
+	// ErrInvalidStatusChange is returned when an account status change is malformed or not allowed
+	ErrInvalidStatusChange = errs.Validation("invalid_status_change", "invalid account status change")
+
+	// ErrActiveRentals is returned when deleting an account that still has
+	// confirmed or picked-up rentals, as renter or as tool owner
+	ErrActiveRentals = errs.Conflict("active_rentals", "account has active rentals")
+
+	// ErrForbidden is returned when the actor may not perform the operation
+	ErrForbidden = errs.Forbidden("user_forbidden", "not allowed to perform this operation")
+)
@@ -0,0 +1,47 @@
+package user
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Identity is what the identity provider asserts about a user when they sign in
+type Identity struct {
+	Email         string
+	EmailVerified bool
+	Name          string
+	PictureURL    string
+}
+
+// SyncIdentity reconciles the user with their identity provider and reports
+// whether anything changed. The provider owns the email address and whether
+// it is verified. The name and picture only fill an empty display name and
+// avatar, so that edits the member made to their profile are kept; values
+// the profile would not accept are ignored.
+func (u *User) SyncIdentity(id Identity) bool {
+	changed := false
+
+	if email := strings.TrimSpace(id.Email); email != "" && (email != u.Email || id.EmailVerified != u.EmailVerified) {
+		u.Email = email
+		u.EmailVerified = id.EmailVerified
+		changed = true
+	}
+
+	if name := strings.TrimSpace(id.Name); u.Profile.DisplayName == "" && name != "" &&
+		utf8.RuneCountInString(name) <= MaxDisplayNameLength {
+		u.Profile.DisplayName = name
+		changed = true
+	}
+
+	if picture := strings.TrimSpace(id.PictureURL); u.Profile.AvatarURL == "" && picture != "" &&
+		checkAvatarURL(picture) == "" {
+		u.Profile.AvatarURL = picture
+		changed = true
+	}
+
+	if changed {
+		u.UpdatedAt = time.Now()
+	}
+	return changed
+}
@@ -0,0 +1,70 @@
+package user
+
+import "testing"
+
+func TestUserSyncIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		identity Identity
+		changed  bool
+		want     User
+	}{
+		{
+			name:     "nothing new",
+			identity: Identity{Email: "dora@example.com"},
+			changed:  false,
+			want:     User{Email: "dora@example.com"},
+		},
+		{
+			name:     "email changed",
+			identity: Identity{Email: " dora@new.example.com ", EmailVerified: true},
+			changed:  true,
+			want:     User{Email: "dora@new.example.com", EmailVerified: true},
+		},
+		{
+			name:     "email verified",
+			identity: Identity{Email: "dora@example.com", EmailVerified: true},
+			changed:  true,
+			want:     User{Email: "dora@example.com", EmailVerified: true},
+		},
+		{
+			name:     "missing email kept",
+			identity: Identity{EmailVerified: true},
+			changed:  false,
+			want:     User{Email: "dora@example.com"},
+		},
+		{
+			name:     "name and picture fill an empty profile",
+			identity: Identity{Email: "dora@example.com", Name: "Dora", PictureURL: "https://example.com/dora.png"},
+			changed:  true,
+			want:     User{Email: "dora@example.com", Profile: Profile{DisplayName: "Dora", AvatarURL: "https://example.com/dora.png"}},
+		},
+		{
+			name:     "profile edits kept",
+			profile:  Profile{DisplayName: "D.", AvatarURL: "https://example.com/d.png"},
+			identity: Identity{Email: "dora@example.com", Name: "Dora", PictureURL: "https://example.com/dora.png"},
+			changed:  false,
+			want:     User{Email: "dora@example.com", Profile: Profile{DisplayName: "D.", AvatarURL: "https://example.com/d.png"}},
+		},
+		{
+			name:     "invalid picture ignored",
+			identity: Identity{Email: "dora@example.com", PictureURL: "data:image/png;base64,AAAA"},
+			changed:  false,
+			want:     User{Email: "dora@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &User{ID: "u1", Email: "dora@example.com", Profile: tt.profile}
+
+			if changed := u.SyncIdentity(tt.identity); changed != tt.changed {
+				t.Errorf("SyncIdentity() = %t, want %t", changed, tt.changed)
+			}
+			if u.Email != tt.want.Email || u.EmailVerified != tt.want.EmailVerified || u.Profile != tt.want.Profile {
+				t.Errorf("user = %q %t %+v, want %q %t %+v",
+					u.Email, u.EmailVerified, u.Profile, tt.want.Email, tt.want.EmailVerified, tt.want.Profile)
+			}
+		})
+	}
+}
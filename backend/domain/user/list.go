@@ -0,0 +1,194 @@
+package user
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Page sizes of user listings
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// SortField is the attribute a user listing is ordered by. Ties are broken
+// by user ID so that every listing has a stable order to page through.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "createdAt"
+	SortByEmail     SortField = "email"
+)
+
+// ListQuery selects and orders a page of users. Zero-valued filters match
+// every user.
+type ListQuery struct {
+	EmailPrefix   string    // case-insensitive prefix of the email address
+	Role          Role      // a role the user holds; the member role matches everyone
+	Status        Status    // the stored account status; deleted accounts are only listed when asked for
+	CreatedAfter  time.Time // created at or after this time
+	CreatedBefore time.Time // created before this time
+	Sort          SortField
+	Descending    bool
+	Limit         int
+	Cursor        string // NextCursor of the previous page
+}
+
+// ListPage is one page of a user listing
+type ListPage struct {
+	Users []*User
+
+	// NextCursor continues the listing after this page; empty on the last page
+	NextCursor string
+}
+
+// Cursor is the decoded position of a listing: the sort key and ID of the
+// last user of a page. It records the order it was issued for, so that it
+// cannot be replayed against a different one.
+type Cursor struct {
+	Sort       SortField `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	Email      string    `json:"e,omitempty"`
+	CreatedAt  time.Time `json:"c"`
+	ID         string    `json:"i"`
+}
+
+// Normalize applies the default order and page size and validates the query,
+// decoding its cursor
+func (q *ListQuery) Normalize() (*Cursor, error) {
+	q.EmailPrefix = strings.ToLower(strings.TrimSpace(q.EmailPrefix))
+
+	if q.Sort == "" {
+		q.Sort = SortByCreatedAt
+	}
+	if q.Sort != SortByCreatedAt && q.Sort != SortByEmail {
+		return nil, ErrInvalidListQuery.WithField("sort", fmt.Sprintf("%q is unknown", q.Sort))
+	}
+	if q.Role != "" && !q.Role.Valid() {
+		return nil, ErrInvalidListQuery.WithField("role", fmt.Sprintf("%q is unknown", q.Role))
+	}
+	if q.Status != "" && !q.Status.Valid() {
+		return nil, ErrInvalidListQuery.WithField("status", fmt.Sprintf("%q is unknown", q.Status))
+	}
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedBefore.After(q.CreatedAfter) {
+		return nil, ErrInvalidListQuery.WithField("createdBefore", "must be after createdAfter")
+	}
+
+	switch {
+	case q.Limit == 0:
+		q.Limit = DefaultListLimit
+	case q.Limit < 0 || q.Limit > MaxListLimit:
+		return nil, ErrInvalidListQuery.WithField("limit", fmt.Sprintf("must be between 1 and %d", MaxListLimit))
+	}
+
+	if q.Cursor == "" {
+		return nil, nil
+	}
+	cursor, err := decodeCursor(q.Cursor)
+	if err != nil || cursor.Sort != q.Sort || cursor.Descending != q.Descending {
+		return nil, ErrInvalidListQuery.WithField("cursor", "is invalid or was issued for a different sort")
+	}
+	return cursor, nil
+}
+
+// Matches reports whether u passes the query's filters
+func (q *ListQuery) Matches(u *User) bool {
+	if q.EmailPrefix != "" && !strings.HasPrefix(strings.ToLower(u.Email), q.EmailPrefix) {
+		return false
+	}
+	if q.Role != "" && !u.HasRole(q.Role) {
+		return false
+	}
+	if q.Status != "" && u.Status != q.Status {
+		return false
+	}
+	if q.Status == "" && u.Status == StatusDeleted {
+		return false
+	}
+	if !q.CreatedAfter.IsZero() && u.CreatedAt.Before(q.CreatedAfter) {
+		return false
+	}
+	if !q.CreatedBefore.IsZero() && !u.CreatedAt.Before(q.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// CursorAfter returns the cursor that continues the listing after u
+func (q *ListQuery) CursorAfter(u *User) string {
+	c := Cursor{Sort: q.Sort, Descending: q.Descending, ID: u.ID}
+	if q.Sort == SortByEmail {
+		c.Email = u.Email
+	} else {
+		c.CreatedAt = u.CreatedAt.UTC()
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Paginate filters, sorts and pages users in memory, for backends that
+// cannot run the query themselves
+func Paginate(users []*User, q ListQuery) (*ListPage, error) {
+	cursor, err := q.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*User, 0, len(users))
+	for _, u := range users {
+		if q.Matches(u) && (cursor == nil || q.compare(u, cursor.Email, cursor.CreatedAt, cursor.ID) > 0) {
+			matched = append(matched, u)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		b := matched[j]
+		return q.compare(matched[i], b.Email, b.CreatedAt, b.ID) < 0
+	})
+
+	page := &ListPage{Users: matched}
+	if len(matched) > q.Limit {
+		page.Users = matched[:q.Limit]
+		page.NextCursor = q.CursorAfter(page.Users[q.Limit-1])
+	}
+	return page, nil
+}
+
+// compare orders u relative to the position given by a sort key and ID,
+// in the query's direction
+func (q *ListQuery) compare(u *User, email string, createdAt time.Time, id string) int {
+	var c int
+	if q.Sort == SortByEmail {
+		c = strings.Compare(u.Email, email)
+	} else {
+		c = u.CreatedAt.Compare(createdAt)
+	}
+	if c == 0 {
+		c = strings.Compare(u.ID, id)
+	}
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
+func decodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if c.ID == "" {
+		return nil, fmt.Errorf("cursor has no ID")
+	}
+	return &c, nil
+}
@@ -0,0 +1,130 @@
+package user
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// directory returns users created a day apart, with an email ordering that
+// differs from their creation order; c and d share a creation time
+func directory() []*User {
+	base := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	users := []*User{
+		{ID: "a", Email: "dora@example.com", CreatedAt: base, Status: StatusActive},
+		{ID: "b", Email: "bob@example.com", CreatedAt: base.Add(24 * time.Hour), Status: StatusActive, Roles: []Role{RoleStaff}},
+		{ID: "c", Email: "carol@example.com", CreatedAt: base.Add(48 * time.Hour), Status: StatusSuspended},
+		{ID: "d", Email: "alice@example.com", CreatedAt: base.Add(48 * time.Hour), Status: StatusActive},
+		{ID: "e", Email: "", CreatedAt: base.Add(72 * time.Hour), Status: StatusDeleted},
+	}
+	return users
+}
+
+// listAll pages through the directory and returns the IDs in listing order
+func listAll(t *testing.T, q ListQuery) []string {
+	t.Helper()
+
+	var ids []string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("listing does not end")
+		}
+		page, err := Paginate(directory(), q)
+		if err != nil {
+			t.Fatalf("Paginate() = %v", err)
+		}
+		if len(page.Users) > q.Limit && q.Limit > 0 {
+			t.Fatalf("page holds %d users, limit is %d", len(page.Users), q.Limit)
+		}
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == "" {
+			return ids
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ListQuery
+		want  []string
+	}{
+		{"created ascending", ListQuery{}, []string{"a", "b", "c", "d"}},
+		{"created ascending by pages", ListQuery{Limit: 1}, []string{"a", "b", "c", "d"}},
+		{"created descending by pages", ListQuery{Descending: true, Limit: 3}, []string{"d", "c", "b", "a"}},
+		{"email ascending by pages", ListQuery{Sort: SortByEmail, Limit: 2}, []string{"d", "b", "c", "a"}},
+		{"email descending by pages", ListQuery{Sort: SortByEmail, Descending: true, Limit: 2}, []string{"a", "c", "b", "d"}},
+		{"ties broken by ID", ListQuery{CreatedAfter: directory()[2].CreatedAt, Limit: 1}, []string{"c", "d"}},
+		{"email prefix ignores case", ListQuery{EmailPrefix: " BO"}, []string{"b"}},
+		{"role", ListQuery{Role: RoleStaff}, []string{"b"}},
+		{"member role matches everyone", ListQuery{Role: RoleMember}, []string{"a", "b", "c", "d"}},
+		{"status", ListQuery{Status: StatusSuspended}, []string{"c"}},
+		{"deleted only when asked for", ListQuery{Status: StatusDeleted}, []string{"e"}},
+		{"created range", ListQuery{CreatedAfter: directory()[1].CreatedAt, CreatedBefore: directory()[2].CreatedAt}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listAll(t, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("listed %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateLastPageHasNoCursor(t *testing.T) {
+	page, err := Paginate(directory(), ListQuery{Limit: 4})
+	if err != nil {
+		t.Fatalf("Paginate() = %v", err)
+	}
+	if len(page.Users) != 4 || page.NextCursor != "" {
+		t.Errorf("got %d users and cursor %q, want 4 users and no cursor", len(page.Users), page.NextCursor)
+	}
+}
+
+func TestListQueryNormalize(t *testing.T) {
+	cursor := (&ListQuery{Sort: SortByEmail}).CursorAfter(directory()[0])
+
+	tests := []struct {
+		name  string
+		query ListQuery
+		field string
+	}{
+		{"defaults", ListQuery{}, ""},
+		{"maximum limit", ListQuery{Limit: MaxListLimit}, ""},
+		{"cursor of the same sort", ListQuery{Sort: SortByEmail, Cursor: cursor}, ""},
+		{"unknown sort", ListQuery{Sort: "name"}, "sort"},
+		{"unknown role", ListQuery{Role: "owner"}, "role"},
+		{"unknown status", ListQuery{Status: "banned"}, "status"},
+		{"empty created range", ListQuery{CreatedAfter: time.Unix(100, 0), CreatedBefore: time.Unix(100, 0)}, "createdBefore"},
+		{"negative limit", ListQuery{Limit: -1}, "limit"},
+		{"limit too large", ListQuery{Limit: MaxListLimit + 1}, "limit"},
+		{"malformed cursor", ListQuery{Cursor: "not a cursor"}, "cursor"},
+		{"cursor of another sort", ListQuery{Sort: SortByCreatedAt, Cursor: cursor}, "cursor"},
+		{"cursor of another direction", ListQuery{Sort: SortByEmail, Descending: true, Cursor: cursor}, "cursor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := tt.query
+			_, err := q.Normalize()
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Normalize() = %v, want nil", err)
+				}
+				if q.Sort == "" || q.Limit == 0 {
+					t.Errorf("Normalize() left sort %q and limit %d", q.Sort, q.Limit)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidListQuery) {
+				t.Fatalf("Normalize() = %v, want ErrInvalidListQuery", err)
+			}
+			if fields := fieldsOf(err); !slices.Equal(fields, []string{tt.field}) {
+				t.Errorf("invalid fields = %v, want [%s]", fields, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package user
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits on the length of profile fields, in characters
+const (
+	MaxDisplayNameLength  = 100
+	MaxBioLength          = 500
+	MaxAvatarURLLength    = 2048
+	MaxAddressFieldLength = 200
+)
+
+// phonePattern accepts international numbers such as "+44 20 7946 0958":
+// an optional leading plus, then digits optionally grouped by spaces,
+// dashes, dots or parentheses
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{5,28}[0-9]$`)
+
+// countryPattern matches an ISO 3166-1 alpha-2 country code such as "GB"
+var countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Address is a member's postal address, used to arrange tool pickups
+type Address struct {
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string // ISO 3166-1 alpha-2 code
+}
+
+// NotificationPreferences selects the notifications a member receives
+type NotificationPreferences struct {
+	RentalUpdates bool // rentals being confirmed, cancelled or becoming overdue
+	Reminders     bool // upcoming pickups and returns
+	SMS           bool // send reminders by text message as well as email
+	Newsletter    bool // club news and events
+}
+
+// DefaultNotificationPreferences are the preferences of a new member: the
+// notifications needed to rent tools, and nothing promotional
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{RentalUpdates: true, Reminders: true}
+}
+
+// Profile holds the details a member shares with the club
+type Profile struct {
+	DisplayName   string
+	Phone         string
+	Address       Address
+	AvatarURL     string
+	Bio           string
+	Notifications NotificationPreferences
+}
+
+// Normalize trims surrounding whitespace from the free-text fields and
+// upper-cases the country code
+func (p *Profile) Normalize() {
+	p.DisplayName = strings.TrimSpace(p.DisplayName)
+	p.Phone = strings.TrimSpace(p.Phone)
+	p.AvatarURL = strings.TrimSpace(p.AvatarURL)
+	p.Bio = strings.TrimSpace(p.Bio)
+	p.Address.Line1 = strings.TrimSpace(p.Address.Line1)
+	p.Address.Line2 = strings.TrimSpace(p.Address.Line2)
+	p.Address.City = strings.TrimSpace(p.Address.City)
+	p.Address.Region = strings.TrimSpace(p.Address.Region)
+	p.Address.PostalCode = strings.TrimSpace(p.Address.PostalCode)
+	p.Address.Country = strings.ToUpper(strings.TrimSpace(p.Address.Country))
+}
+
+// Validate checks the profile's invariants, reporting every invalid field.
+// All fields are optional; those that are set must be well-formed.
+func (p Profile) Validate() error {
+	err := ErrInvalidProfile
+	invalid := false
+	report := func(field, message string) {
+		err = err.WithField(field, message)
+		invalid = true
+	}
+
+	if utf8.RuneCountInString(p.DisplayName) > MaxDisplayNameLength {
+		report("displayName", fmt.Sprintf("must be at most %d characters", MaxDisplayNameLength))
+	}
+	if p.Phone != "" && !phonePattern.MatchString(p.Phone) {
+		report("phone", "must be a phone number such as +44 20 7946 0958")
+	}
+	if p.AvatarURL != "" {
+		if message := checkAvatarURL(p.AvatarURL); message != "" {
+			report("avatarUrl", message)
+		}
+	}
+	if utf8.RuneCountInString(p.Bio) > MaxBioLength {
+		report("bio", fmt.Sprintf("must be at most %d characters", MaxBioLength))
+	}
+
+	addressFields := []struct{ name, value string }{
+		{"address.line1", p.Address.Line1},
+		{"address.line2", p.Address.Line2},
+		{"address.city", p.Address.City},
+		{"address.region", p.Address.Region},
+		{"address.postalCode", p.Address.PostalCode},
+	}
+	for _, f := range addressFields {
+		if utf8.RuneCountInString(f.value) > MaxAddressFieldLength {
+			report(f.name, fmt.Sprintf("must be at most %d characters", MaxAddressFieldLength))
+		}
+	}
+	if p.Address.Country != "" && !countryPattern.MatchString(p.Address.Country) {
+		report("address.country", "must be a two-letter ISO 3166 country code")
+	}
+
+	if p.Notifications.SMS && p.Phone == "" {
+		report("notifications.sms", "requires a phone number")
+	}
+
+	if invalid {
+		return err
+	}
+	return nil
+}
+
+// checkAvatarURL describes what is wrong with an avatar URL, if anything.
+// Avatars are shown to other members, so only absolute http(s) URLs are allowed.
+func checkAvatarURL(raw string) string {
+	if len(raw) > MaxAvatarURLLength {
+		return fmt.Sprintf("must be at most %d characters", MaxAvatarURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "must be an absolute http or https URL"
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/toolrentalclub/domain/errs"
+)
+
+func TestProfileValidate(t *testing.T) {
+	valid := Profile{
+		DisplayName: "Dora the Driller",
+		Phone:       "+44 20 7946 0958",
+		Address:     Address{Line1: "1 High Street", City: "London", PostalCode: "SW1A 1AA", Country: "GB"},
+		AvatarURL:   "https://example.com/dora.png",
+		Bio:         "I lend power tools.",
+		Notifications: NotificationPreferences{
+			RentalUpdates: true,
+			SMS:           true,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		change func(p *Profile)
+		fields []string
+	}{
+		{"valid", func(p *Profile) {}, nil},
+		{"empty", func(p *Profile) { *p = Profile{} }, nil},
+		{"display name at limit", func(p *Profile) { p.DisplayName = strings.Repeat("é", MaxDisplayNameLength) }, nil},
+		{"display name too long", func(p *Profile) { p.DisplayName = strings.Repeat("é", MaxDisplayNameLength+1) }, []string{"displayName"}},
+		{"phone with punctuation", func(p *Profile) { p.Phone = "+1 (555) 010.0199" }, nil},
+		{"phone starting with a parenthesis", func(p *Profile) { p.Phone = "(020) 7946-0958" }, []string{"phone"}},
+		{"phone with letters", func(p *Profile) { p.Phone = "call me maybe" }, []string{"phone"}},
+		{"phone too short", func(p *Profile) { p.Phone = "12345" }, []string{"phone"}},
+		{"http avatar", func(p *Profile) { p.AvatarURL = "http://example.com/a.png" }, nil},
+		{"relative avatar", func(p *Profile) { p.AvatarURL = "/a.png" }, []string{"avatarUrl"}},
+		{"javascript avatar", func(p *Profile) { p.AvatarURL = "javascript:alert(1)" }, []string{"avatarUrl"}},
+		{"avatar too long", func(p *Profile) { p.AvatarURL = "https://example.com/" + strings.Repeat("a", MaxAvatarURLLength) }, []string{"avatarUrl"}},
+		{"bio too long", func(p *Profile) { p.Bio = strings.Repeat("a", MaxBioLength+1) }, []string{"bio"}},
+		{"address line too long", func(p *Profile) { p.Address.Line2 = strings.Repeat("a", MaxAddressFieldLength+1) }, []string{"address.line2"}},
+		{"lower-case country", func(p *Profile) { p.Address.Country = "gb" }, []string{"address.country"}},
+		{"three-letter country", func(p *Profile) { p.Address.Country = "GBR" }, []string{"address.country"}},
+		{"sms without phone", func(p *Profile) { p.Phone = "" }, []string{"notifications.sms"}},
+		{
+			"every invalid field reported",
+			func(p *Profile) { p.Phone = "x"; p.Bio = strings.Repeat("a", MaxBioLength+1); p.Address.Country = "G" },
+			[]string{"phone", "bio", "address.country"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.change(&p)
+
+			err := p.Validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidProfile) {
+				t.Fatalf("Validate() = %v, want ErrInvalidProfile", err)
+			}
+			if fields := fieldsOf(err); !slices.Equal(fields, tt.fields) {
+				t.Errorf("invalid fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestProfileNormalize(t *testing.T) {
+	p := Profile{
+		DisplayName: "  Dora ",
+		Phone:       " +44 20 7946 0958\n",
+		Address:     Address{City: " London ", Country: " gb "},
+	}
+	p.Normalize()
+
+	if p.DisplayName != "Dora" || p.Phone != "+44 20 7946 0958" || p.Address.City != "London" || p.Address.Country != "GB" {
+		t.Errorf("Normalize() = %+v", p)
+	}
+}
+
+// fieldsOf returns the names of the invalid fields a validation error reports
+func fieldsOf(err error) []string {
+	domainErr, ok := errs.As(err)
+	if !ok {
+		return nil
+	}
+	fields := make([]string, len(domainErr.Fields))
+	for i, f := range domainErr.Fields {
+		fields[i] = f.Field
+	}
+	return fields
+}
@@ -15,5 +15,9 @@ type Repository interface {
 	
 	// Update updates an existing user
 	Update(ctx context.Context, user *User) error
+
+	// List retrieves a page of the users matching the query, in the query's
+	// order (see ListQuery)
+	List(ctx context.Context, query ListQuery) (*ListPage, error)
 }
 
@@ -0,0 +1,130 @@
+package user
+
+// Role is a set of permissions granted to a member of the club
+type Role string
+
+const (
+	// RoleMember can browse the catalog and rent tools. Every user is a member.
+	RoleMember Role = "member"
+
+	// RoleToolOwner can also lend their own tools through the club
+	RoleToolOwner Role = "tool_owner"
+
+	// RoleStaff runs the club day to day and can act on any tool or rental
+	RoleStaff Role = "staff"
+
+	// RoleAdmin can do everything, including granting and revoking roles
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists every role from least to most privileged
+var Roles = []Role{RoleMember, RoleToolOwner, RoleStaff, RoleAdmin}
+
+// Permission is an action a role allows
+type Permission string
+
+const (
+	// PermissionRentTools allows requesting rentals
+	PermissionRentTools Permission = "rentals:create"
+
+	// PermissionListTools allows adding tools to the catalog
+	PermissionListTools Permission = "tools:create"
+
+	// PermissionManageAnyTool allows editing and blocking tools owned by others
+	PermissionManageAnyTool Permission = "tools:manage_any"
+
+	// PermissionManageAnyRental allows viewing and moving any rental through its lifecycle
+	PermissionManageAnyRental Permission = "rentals:manage_any"
+
+	// PermissionViewUsers allows looking up other users
+	PermissionViewUsers Permission = "users:read"
+
+	// PermissionManageMembers allows approving, suspending, reinstating and
+	// closing member accounts
+	PermissionManageMembers Permission = "users:manage"
+
+	// PermissionManageRoles allows granting and revoking roles
+	PermissionManageRoles Permission = "roles:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleMember:    {PermissionRentTools},
+	RoleToolOwner: {PermissionRentTools, PermissionListTools},
+	RoleStaff: {
+		PermissionRentTools, PermissionListTools, PermissionManageAnyTool,
+		PermissionManageAnyRental, PermissionViewUsers, PermissionManageMembers,
+	},
+	RoleAdmin: {
+		PermissionRentTools, PermissionListTools, PermissionManageAnyTool,
+		PermissionManageAnyRental, PermissionViewUsers, PermissionManageMembers,
+		PermissionManageRoles,
+	},
+}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions granted by the role
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// HasPermission reports whether any of the roles, or the member role every
+// user holds, grants the permission
+func HasPermission(roles []Role, p Permission) bool {
+	for _, r := range append([]Role{RoleMember}, roles...) {
+		for _, granted := range rolePermissions[r] {
+			if granted == p {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseRoles converts role names to roles, dropping unknown names and
+// duplicates and ordering the result from least to most privileged
+func ParseRoles(names []string) []Role {
+	seen := make(map[Role]bool, len(names))
+	for _, name := range names {
+		seen[Role(name)] = true
+	}
+
+	roles := make([]Role, 0, len(seen))
+	for _, r := range Roles {
+		if seen[r] {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// MergeRoles combines role sets without duplicates, ordered from least to most privileged
+func MergeRoles(sets ...[]Role) []Role {
+	var names []string
+	for _, set := range sets {
+		for _, r := range set {
+			names = append(names, string(r))
+		}
+	}
+	return ParseRoles(names)
+}
+
+// PermissionsFor lists the permissions granted by the roles and the implicit
+// member role, without duplicates
+func PermissionsFor(roles []Role) []Permission {
+	seen := make(map[Permission]bool)
+	var permissions []Permission
+	for _, r := range MergeRoles([]Role{RoleMember}, roles) {
+		for _, p := range rolePermissions[r] {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	return permissions
+}
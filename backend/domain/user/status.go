@@ -0,0 +1,133 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Status describes whether a member may use the club
+type Status string
+
+const (
+	// StatusPending accounts await approval by staff before they can be used
+	StatusPending Status = "pending"
+
+	// StatusActive accounts can be used normally
+	StatusActive Status = "active"
+
+	// StatusSuspended accounts are barred, indefinitely or until SuspendedUntil
+	StatusSuspended Status = "suspended"
+
+	// StatusClosed accounts have been shut by the club
+	StatusClosed Status = "closed"
+
+	// StatusDeleted accounts were deleted by their member. Only a tombstone
+	// holding the ID is kept, so that a token issued before the deletion
+	// cannot sign the member back in as a new user.
+	StatusDeleted Status = "deleted"
+)
+
+// Valid reports whether the status is one of the known values
+func (s Status) Valid() bool {
+	switch s {
+	case StatusPending, StatusActive, StatusSuspended, StatusClosed, StatusDeleted:
+		return true
+	}
+	return false
+}
+
+// EffectiveStatus returns the user's status at now. A suspension whose
+// expiry has passed no longer applies, even before it is lifted in storage.
+func (u *User) EffectiveStatus(now time.Time) Status {
+	if u.Status == StatusSuspended && !u.SuspendedUntil.IsZero() && !now.Before(u.SuspendedUntil) {
+		return StatusActive
+	}
+	return u.Status
+}
+
+// CheckActive returns the error explaining why the user cannot use the club
+// at now, or nil if they are active
+func (u *User) CheckActive(now time.Time) error {
+	switch u.EffectiveStatus(now) {
+	case StatusActive:
+		return nil
+	case StatusPending:
+		return ErrAccountPending
+	case StatusSuspended:
+		if u.SuspendedUntil.IsZero() {
+			return fmt.Errorf("%w: %s", ErrAccountSuspended, u.StatusReason)
+		}
+		return fmt.Errorf("%w until %s: %s", ErrAccountSuspended, u.SuspendedUntil.UTC().Format(time.RFC3339), u.StatusReason)
+	case StatusDeleted:
+		return ErrAccountDeleted
+	default:
+		return fmt.Errorf("%w: %s", ErrAccountClosed, u.StatusReason)
+	}
+}
+
+// Suspend bars the user from the club until the given time, or indefinitely
+// if until is zero
+func (u *User) Suspend(reason string, until time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrInvalidStatusChange.WithField("reason", "is required")
+	}
+	if !until.IsZero() && !until.After(time.Now()) {
+		return ErrInvalidStatusChange.WithField("until", "must be in the future")
+	}
+	switch u.Status {
+	case StatusClosed:
+		return fmt.Errorf("%w: closed accounts cannot be suspended", ErrInvalidStatusChange)
+	case StatusDeleted:
+		return errDeletedStatusChange
+	}
+
+	u.setStatus(StatusSuspended, reason, until)
+	return nil
+}
+
+// Close shuts the user's account
+func (u *User) Close(reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrInvalidStatusChange.WithField("reason", "is required")
+	}
+	if u.Status == StatusDeleted {
+		return errDeletedStatusChange
+	}
+
+	u.setStatus(StatusClosed, reason, time.Time{})
+	return nil
+}
+
+// Reinstate makes the account active: it approves a pending account, lifts
+// a suspension or reopens a closed account. Deleted accounts stay deleted.
+func (u *User) Reinstate() error {
+	if u.Status == StatusDeleted {
+		return errDeletedStatusChange
+	}
+
+	u.setStatus(StatusActive, "", time.Time{})
+	return nil
+}
+
+// Erase turns the user into the tombstone of a deleted account: everything
+// that identifies them is cleared and only the ID is kept
+func (u *User) Erase() {
+	u.Email = ""
+	u.EmailVerified = false
+	u.Roles = nil
+	u.Profile = Profile{}
+	u.setStatus(StatusDeleted, "", time.Time{})
+}
+
+// errDeletedStatusChange is returned when changing the status of a deleted account
+var errDeletedStatusChange = fmt.Errorf("%w: deleted accounts cannot be changed", ErrInvalidStatusChange)
+
+func (u *User) setStatus(status Status, reason string, until time.Time) {
+	u.Status = status
+	u.StatusReason = reason
+	u.SuspendedUntil = until
+	u.UpdatedAt = time.Now()
+}
@@ -0,0 +1,112 @@
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUserCheckActive(t *testing.T) {
+	now := time.Date(2030, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		user  User
+		want  error
+		state Status
+	}{
+		{"active", User{Status: StatusActive}, nil, StatusActive},
+		{"pending", User{Status: StatusPending}, ErrAccountPending, StatusPending},
+		{"suspended indefinitely", User{Status: StatusSuspended, StatusReason: "damaged a saw"}, ErrAccountSuspended, StatusSuspended},
+		{"suspended until later", User{Status: StatusSuspended, SuspendedUntil: now.Add(time.Hour)}, ErrAccountSuspended, StatusSuspended},
+		{"suspension ends now", User{Status: StatusSuspended, SuspendedUntil: now}, nil, StatusActive},
+		{"suspension ended", User{Status: StatusSuspended, SuspendedUntil: now.Add(-time.Hour)}, nil, StatusActive},
+		{"closed", User{Status: StatusClosed}, ErrAccountClosed, StatusClosed},
+		{"deleted", User{Status: StatusDeleted}, ErrAccountDeleted, StatusDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.EffectiveStatus(now); got != tt.state {
+				t.Errorf("EffectiveStatus() = %s, want %s", got, tt.state)
+			}
+			if err := tt.user.CheckActive(now); !errors.Is(err, tt.want) {
+				t.Errorf("CheckActive() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserStatusChanges(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+
+	suspend := func(reason string, until time.Time) func(u *User) error {
+		return func(u *User) error { return u.Suspend(reason, until) }
+	}
+	closeAccount := func(reason string) func(u *User) error {
+		return func(u *User) error { return u.Close(reason) }
+	}
+	reinstate := func(u *User) error { return u.Reinstate() }
+
+	tests := []struct {
+		name   string
+		from   Status
+		change func(u *User) error
+		want   Status // the status afterwards; unchanged if the change fails
+		fails  bool
+	}{
+		{"suspend active", StatusActive, suspend("late returns", time.Time{}), StatusSuspended, false},
+		{"suspend until later", StatusActive, suspend("late returns", later), StatusSuspended, false},
+		{"suspend pending", StatusPending, suspend("spam", time.Time{}), StatusSuspended, false},
+		{"suspend without reason", StatusActive, suspend("  ", time.Time{}), StatusActive, true},
+		{"suspend until the past", StatusActive, suspend("late returns", time.Now().Add(-time.Hour)), StatusActive, true},
+		{"suspend closed", StatusClosed, suspend("late returns", time.Time{}), StatusClosed, true},
+		{"suspend deleted", StatusDeleted, suspend("late returns", time.Time{}), StatusDeleted, true},
+		{"close active", StatusActive, closeAccount("moved away"), StatusClosed, false},
+		{"close suspended", StatusSuspended, closeAccount("moved away"), StatusClosed, false},
+		{"close without reason", StatusActive, closeAccount(""), StatusActive, true},
+		{"close deleted", StatusDeleted, closeAccount("moved away"), StatusDeleted, true},
+		{"reinstate pending", StatusPending, reinstate, StatusActive, false},
+		{"reinstate suspended", StatusSuspended, reinstate, StatusActive, false},
+		{"reinstate closed", StatusClosed, reinstate, StatusActive, false},
+		{"reinstate deleted", StatusDeleted, reinstate, StatusDeleted, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUser("u1", "dora@example.com")
+			u.Status = tt.from
+
+			err := tt.change(u)
+			if tt.fails != (err != nil) {
+				t.Fatalf("change returned %v, want failure %t", err, tt.fails)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidStatusChange) {
+				t.Errorf("change returned %v, want ErrInvalidStatusChange", err)
+			}
+			if u.Status != tt.want {
+				t.Errorf("Status = %s, want %s", u.Status, tt.want)
+			}
+			if u.Status == StatusActive && (u.StatusReason != "" || !u.SuspendedUntil.IsZero()) {
+				t.Errorf("active user kept reason %q and suspension end %s", u.StatusReason, u.SuspendedUntil)
+			}
+		})
+	}
+}
+
+func TestUserErase(t *testing.T) {
+	u := NewUser("u1", "dora@example.com")
+	u.EmailVerified = true
+	u.Roles = []Role{RoleToolOwner}
+	u.Profile.DisplayName = "Dora"
+
+	u.Erase()
+
+	if u.ID != "u1" {
+		t.Errorf("ID = %q, want it kept", u.ID)
+	}
+	if u.Email != "" || u.EmailVerified || u.Roles != nil || u.Profile != (Profile{}) {
+		t.Errorf("Erase() kept identifying data: %+v", u)
+	}
+	if u.Status != StatusDeleted {
+		t.Errorf("Status = %s, want %s", u.Status, StatusDeleted)
+	}
+}
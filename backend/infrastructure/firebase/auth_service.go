@@ -3,49 +3,150 @@ package firebase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
+	firebaseAuth "firebase.google.com/go/v4/auth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/tracing"
 )
 
+var tracer = otel.Tracer("github.com/yourusername/toolrentalclub/infrastructure/firebase")
+
 // AuthService implements the auth.Service interface using Firebase
 type AuthService struct {
-	app *firebase.App
+	client *firebaseAuth.Client
 }
 
-// NewAuthService creates a new Firebase auth service
-func NewAuthService(app *firebase.App) *AuthService {
-	return &AuthService{
-		app: app,
+// NewAuthService creates a new Firebase auth service. The auth client is
+// created once and shared by every verification.
+func NewAuthService(ctx context.Context, app *firebase.App) (*AuthService, error) {
+	if app == nil {
+		return nil, fmt.Errorf("firebase app not initialized")
+	}
+
+	client, err := app.Auth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth client: %w", err)
 	}
+
+	return &AuthService{
+		client: client,
+	}, nil
 }
 
-// VerifyToken verifies a Firebase ID token and returns token information
-func (s *AuthService) VerifyToken(ctx context.Context, tokenValue string) (*auth.Token, error) {
-	if s.app == nil {
-		return nil, fmt.Errorf("firebase app not initialized")
+// VerifyToken verifies a Firebase ID token and returns token information.
+// Only the signature and claims are checked, against cached public keys.
+func (s *AuthService) VerifyToken(ctx context.Context, tokenValue string) (_ *auth.Token, err error) {
+	ctx, span := tracer.Start(ctx, "firebase.VerifyToken", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
+	firebaseToken, err := s.client.VerifyIDToken(ctx, tokenValue)
+	if err != nil {
+		return nil, verificationError(err)
 	}
 
-	// Get Firebase Auth client
-	client, err := s.app.Auth(ctx)
+	return toDomainToken(tokenValue, firebaseToken), nil
+}
+
+// VerifyTokenAndCheckRevoked verifies a Firebase ID token and asks Firebase
+// whether it has been revoked or the user disabled
+func (s *AuthService) VerifyTokenAndCheckRevoked(ctx context.Context, tokenValue string) (_ *auth.Token, err error) {
+	ctx, span := tracer.Start(ctx, "firebase.VerifyTokenAndCheckRevoked", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
+	firebaseToken, err := s.client.VerifyIDTokenAndCheckRevoked(ctx, tokenValue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get auth client: %w", err)
+		if firebaseAuth.IsIDTokenRevoked(err) || firebaseAuth.IsUserDisabled(err) {
+			return nil, fmt.Errorf("%w: %v", auth.ErrTokenRevoked, err)
+		}
+		return nil, verificationError(err)
+	}
+
+	return toDomainToken(tokenValue, firebaseToken), nil
+}
+
+// DeleteAccount deletes the user's Firebase account through the Admin SDK,
+// which also revokes their refresh tokens
+func (s *AuthService) DeleteAccount(ctx context.Context, userID string) (err error) {
+	ctx, span := tracer.Start(ctx, "firebase.DeleteAccount", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.client.DeleteUser(ctx, userID); err != nil && !firebaseAuth.IsUserNotFound(err) {
+		return fmt.Errorf("failed to delete Firebase account: %w", err)
 	}
+	return nil
+}
+
+// LookupRoles reads the roles granted to a user through Firebase custom
+// claims, which requires a round trip to Firebase
+func (s *AuthService) LookupRoles(ctx context.Context, userID string) (_ []string, err error) {
+	ctx, span := tracer.Start(ctx, "firebase.LookupRoles", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
 
-	// Verify the token
-	firebaseToken, err := client.VerifyIDToken(ctx, tokenValue)
+	record, err := s.client.GetUser(ctx, userID)
+	if firebaseAuth.IsUserNotFound(err) {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("invalid or expired token: %w", err)
+		return nil, fmt.Errorf("failed to look up Firebase user: %w", err)
 	}
+	return rolesFromClaims(record.CustomClaims), nil
+}
+
+// verificationError maps a Firebase verification failure to a domain error.
+// Failing to fetch Google's public keys says nothing about the token, so it
+// is reported as the provider being unavailable rather than the token invalid.
+func verificationError(err error) error {
+	if firebaseAuth.IsCertificateFetchFailed(err) {
+		return fmt.Errorf("%w: %v", auth.ErrProviderUnavailable, err)
+	}
+	return fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+}
 
+// toDomainToken converts a verified Firebase ID token to a domain token
+func toDomainToken(tokenValue string, firebaseToken *firebaseAuth.Token) *auth.Token {
 	// Extract email from claims
 	email := ""
 	if emailClaim, ok := firebaseToken.Claims["email"].(string); ok {
 		email = emailClaim
 	}
 
-	// Create domain token
 	token := auth.NewToken(tokenValue, firebaseToken.UID, email)
-	return token, nil
+	token.EmailVerified, _ = firebaseToken.Claims["email_verified"].(bool)
+	token.Name, _ = firebaseToken.Claims["name"].(string)
+	token.Picture, _ = firebaseToken.Claims["picture"].(string)
+	token.Roles = rolesFromClaims(firebaseToken.Claims)
+	token.Provider = "firebase"
+	token.ExpiresAt = time.Unix(firebaseToken.Expires, 0)
+	token.AuthTime = time.Unix(firebaseToken.AuthTime, 0)
+	return token
+}
+
+// rolesFromClaims reads roles from Firebase custom claims. Roles may be set
+// as a "roles" array, a single "role" string, or an "admin": true flag.
+func rolesFromClaims(claims map[string]interface{}) []string {
+	var roles []string
+
+	if list, ok := claims["roles"].([]interface{}); ok {
+		for _, v := range list {
+			if role, ok := v.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	if role, ok := claims["role"].(string); ok {
+		roles = append(roles, role)
+	}
+
+	if admin, ok := claims["admin"].(bool); ok && admin {
+		roles = append(roles, "admin")
+	}
+
+	return roles
 }
 
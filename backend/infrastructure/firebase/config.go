@@ -41,9 +41,25 @@ func InitializeApp(ctx context.Context) (*firebase.App, error) {
 		}
 		log.Println("Firebase initialized successfully from file")
 		return app, nil
+	} else if emulatorHost := os.Getenv("FIRESTORE_EMULATOR_HOST"); emulatorHost != "" {
+		// The emulator needs no credentials, only a project ID
+		projectID := os.Getenv("FIREBASE_PROJECT_ID")
+		if projectID == "" {
+			projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		}
+		if projectID == "" {
+			projectID = "demo-toolrentalclub"
+		}
+
+		app, err := firebase.NewApp(ctx, &firebase.Config{ProjectID: projectID}, option.WithoutAuthentication())
+		if err != nil {
+			return nil, fmt.Errorf("error initializing firebase app for emulator: %w", err)
+		}
+		log.Printf("Firebase initialized without credentials for the Firestore emulator at %s (project %s)", emulatorHost, projectID)
+		return app, nil
 	}
 
-	log.Println("WARNING: Neither FIREBASE_CREDENTIALS_JSON nor FIREBASE_SERVICE_ACCOUNT set. Firebase features will not work.")
+	log.Println("WARNING: None of FIREBASE_CREDENTIALS_JSON, FIREBASE_SERVICE_ACCOUNT or FIRESTORE_EMULATOR_HOST set. Firebase features will not work.")
 	return nil, nil
 }
 
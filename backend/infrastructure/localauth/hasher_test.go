@@ -0,0 +1,35 @@
+package localauth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+func TestBcryptHasher(t *testing.T) {
+	h := NewBcryptHasher()
+
+	hash, err := h.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("Hash() = %v", err)
+	}
+	if hash == "correct horse" {
+		t.Fatal("Hash() returned the password")
+	}
+
+	if err := h.Compare(hash, "correct horse"); err != nil {
+		t.Errorf("Compare() with the password = %v", err)
+	}
+	if err := h.Compare(hash, "battery staple"); !errors.Is(err, auth.ErrInvalidCredentials) {
+		t.Errorf("Compare() with another password = %v, want ErrInvalidCredentials", err)
+	}
+
+	again, err := h.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("Hash() = %v", err)
+	}
+	if again == hash {
+		t.Error("Hash() returned the same hash twice, want a fresh salt")
+	}
+}
@@ -0,0 +1,180 @@
+package localauth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+// signingKey is a private key identified by its key ID (kid)
+type signingKey struct {
+	id     string
+	method jwt.SigningMethod
+	signer crypto.Signer
+}
+
+// KeySet holds the keys used to sign and verify tokens. One key is active
+// and signs new tokens; the others are kept so that tokens signed before a
+// rotation remain valid until they expire.
+type KeySet struct {
+	active *signingKey
+	keys   map[string]*signingKey
+}
+
+// LoadKeySet loads every PEM-encoded PKCS#8 private key (RSA or Ed25519) in
+// dir. The file name without extension becomes the key ID. The active key is
+// activeID, or the last key ID in lexical order when activeID is empty, so
+// rotating keys is a matter of adding a file with a later name such as
+// "2026-10.pem" and removing the old one once its tokens have expired.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.pem keys found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	set := &KeySet{keys: make(map[string]*signingKey)}
+	for _, path := range paths {
+		key, err := loadKey(path)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[key.id] = key
+		set.active = key
+	}
+
+	if activeID != "" {
+		active, ok := set.keys[activeID]
+		if !ok {
+			return nil, fmt.Errorf("active key %q not found in %s", activeID, dir)
+		}
+		set.active = active
+	}
+
+	return set, nil
+}
+
+// GenerateKeySet creates a key set with a single ephemeral Ed25519 key.
+// Tokens signed with it stop verifying when the process restarts.
+func GenerateKeySet() (*KeySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{id: "ephemeral", method: jwt.SigningMethodEdDSA, signer: private}
+	return &KeySet{
+		active: key,
+		keys:   map[string]*signingKey{key.id: key},
+	}, nil
+}
+
+// ActiveKeyID returns the ID of the key that signs new tokens
+func (s *KeySet) ActiveKeyID() string {
+	return s.active.id
+}
+
+// PublicKeys returns every key in the set in JSON Web Key form
+func (s *KeySet) PublicKeys() []auth.JSONWebKey {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := make([]auth.JSONWebKey, 0, len(ids))
+	for _, id := range ids {
+		key := s.keys[id]
+		jwk := auth.JSONWebKey{
+			KeyID:     key.id,
+			Algorithm: key.method.Alg(),
+			Use:       "sig",
+		}
+
+		switch public := key.signer.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.Modulus = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
+
+// verificationKey returns the public key for a token header's kid and
+// checks that the token was signed with the algorithm of that key
+func (s *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+
+	return key.signer.Public(), nil
+}
+
+// sign signs claims with the active key, recording its ID in the kid header
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.active.method, claims)
+	token.Header["kid"] = s.active.id
+
+	return token.SignedString(s.active.signer)
+}
+
+func loadKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("%s: RSA keys must be at least 2048 bits", path)
+		}
+		return &signingKey{id: id, method: jwt.SigningMethodRS256, signer: private}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{id: id, method: jwt.SigningMethodEdDSA, signer: private}, nil
+	}
+
+	return nil, fmt.Errorf("%s: unsupported key type %T (expected RSA or Ed25519)", path, parsed)
+}
@@ -0,0 +1,131 @@
+package localauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeKey stores key in dir as a PEM-encoded PKCS#8 file named id.pem
+func writeKey(t *testing.T, dir, id string, key crypto.Signer) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() = %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, id+".pem"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() = %v", err)
+	}
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() = %v", err)
+	}
+	return key
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01", newRSAKey(t, 2048))
+	writeKey(t, dir, "2026-10", newEd25519Key(t))
+
+	tests := []struct {
+		name     string
+		activeID string
+		want     string
+		wantErr  string
+	}{
+		{"latest key by default", "", "2026-10", ""},
+		{"configured key", "2026-01", "2026-01", ""},
+		{"unknown configured key", "2025-01", "", `active key "2025-01" not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := LoadKeySet(dir, tt.activeID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadKeySet() = %v, want an error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKeySet() = %v", err)
+			}
+
+			if got := keys.ActiveKeyID(); got != tt.want {
+				t.Errorf("ActiveKeyID() = %q, want %q", got, tt.want)
+			}
+
+			jwks := keys.PublicKeys()
+			if len(jwks) != 2 {
+				t.Fatalf("PublicKeys() returned %d keys, want 2", len(jwks))
+			}
+			if jwks[0].KeyID != "2026-01" || jwks[0].KeyType != "RSA" || jwks[0].Algorithm != "RS256" {
+				t.Errorf("PublicKeys()[0] = %+v, want the RS256 key 2026-01", jwks[0])
+			}
+			if jwks[1].KeyID != "2026-10" || jwks[1].KeyType != "OKP" || jwks[1].Algorithm != "EdDSA" {
+				t.Errorf("PublicKeys()[1] = %+v, want the EdDSA key 2026-10", jwks[1])
+			}
+		})
+	}
+}
+
+func TestLoadKeySetRejectsKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		write   func(t *testing.T, dir string)
+		wantErr string
+	}{
+		{"no keys", func(t *testing.T, dir string) {}, "no *.pem keys found"},
+		{"RSA key under 2048 bits", func(t *testing.T, dir string) {
+			writeKey(t, dir, "weak", newRSAKey(t, 1024))
+		}, "at least 2048 bits"},
+		{"unsupported key type", func(t *testing.T, dir string) {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatalf("ecdsa.GenerateKey() = %v", err)
+			}
+			writeKey(t, dir, "ec", key)
+		}, "unsupported key type"},
+		{"not PEM", func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "junk.pem"), []byte("junk"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}, "no PEM block found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.write(t, dir)
+
+			if _, err := LoadKeySet(dir, ""); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadKeySet() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package localauth is a self-hosted authentication provider that issues
+// and verifies signed JWTs, for clubs that do not want to depend on Firebase.
+package localauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+// Token purposes, recorded in the "purpose" claim so that a password reset
+// token can never be used as an access token and vice versa
+const (
+	purposeAccess        = "access"
+	purposePasswordReset = "password_reset"
+)
+
+// resetTokenTTL is how long a password reset token stays valid
+const resetTokenTTL = 30 * time.Minute
+
+// Config holds the settings of the self-hosted provider
+type Config struct {
+	Issuer   string
+	Audience string
+	TokenTTL time.Duration
+}
+
+// claims are the JWT claims issued by the provider
+type claims struct {
+	jwt.RegisteredClaims
+	Email               string `json:"email,omitempty"`
+	Purpose             string `json:"purpose"`
+	PasswordFingerprint string `json:"pwf,omitempty"`
+}
+
+// Provider implements auth.Service and auth.TokenIssuer with JWTs signed by
+// keys from a KeySet
+type Provider struct {
+	keys *KeySet
+	cfg  Config
+}
+
+// NewProvider creates a new self-hosted auth provider
+func NewProvider(keys *KeySet, cfg Config) *Provider {
+	return &Provider{
+		keys: keys,
+		cfg:  cfg,
+	}
+}
+
+// VerifyToken verifies an access token and returns token information
+func (p *Provider) VerifyToken(ctx context.Context, tokenValue string) (*auth.Token, error) {
+	c, err := p.parse(tokenValue, purposeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	token := auth.NewToken(tokenValue, c.Subject, c.Email)
+	token.Provider = "jwt"
+	token.ExpiresAt = c.ExpiresAt.Time
+	if c.IssuedAt != nil {
+		// Access tokens are only issued on sign-in, so they were issued at auth time
+		token.AuthTime = c.IssuedAt.Time
+	}
+	return token, nil
+}
+
+// IssueAccessToken issues a token that authenticates the user on API requests
+func (p *Provider) IssueAccessToken(ctx context.Context, userID, email string) (*auth.IssuedToken, error) {
+	return p.issue(claims{Email: email, Purpose: purposeAccess}, userID, p.cfg.TokenTTL)
+}
+
+// IssueResetToken issues a short-lived password reset token bound to the
+// user's current password fingerprint
+func (p *Provider) IssueResetToken(ctx context.Context, userID, fingerprint string) (*auth.IssuedToken, error) {
+	return p.issue(claims{Purpose: purposePasswordReset, PasswordFingerprint: fingerprint}, userID, resetTokenTTL)
+}
+
+// VerifyResetToken validates a password reset token
+func (p *Provider) VerifyResetToken(ctx context.Context, tokenValue string) (string, string, error) {
+	c, err := p.parse(tokenValue, purposePasswordReset)
+	if err != nil {
+		return "", "", auth.ErrInvalidResetToken
+	}
+
+	return c.Subject, c.PasswordFingerprint, nil
+}
+
+// PublicKeys returns the keys that verify issued tokens, for the JWKS endpoint
+func (p *Provider) PublicKeys() []auth.JSONWebKey {
+	return p.keys.PublicKeys()
+}
+
+// Check signs and verifies a probe token with the active key
+func (p *Provider) Check(ctx context.Context) error {
+	issued, err := p.issue(claims{Purpose: purposeAccess}, "health-check", time.Minute)
+	if err != nil {
+		return err
+	}
+	if _, err := p.parse(issued.Value, purposeAccess); err != nil {
+		return fmt.Errorf("failed to verify probe token: %w", err)
+	}
+	return nil
+}
+
+func (p *Provider) issue(c claims, subject string, ttl time.Duration) (*auth.IssuedToken, error) {
+	jti, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	c.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        jti,
+		Issuer:    p.cfg.Issuer,
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{p.cfg.Audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	signed, err := p.keys.sign(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &auth.IssuedToken{Value: signed, ExpiresAt: expiresAt}, nil
+}
+
+// parse verifies the signature, time claims, issuer, audience and purpose of a token
+func (p *Provider) parse(tokenValue, purpose string) (*claims, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(
+		tokenValue,
+		&c,
+		p.keys.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidToken, err)
+	}
+
+	if !c.VerifyIssuer(p.cfg.Issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer", auth.ErrInvalidToken)
+	}
+	if !c.VerifyAudience(p.cfg.Audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience", auth.ErrInvalidToken)
+	}
+	if c.ExpiresAt == nil {
+		return nil, fmt.Errorf("%w: missing expiry", auth.ErrInvalidToken)
+	}
+	if c.Purpose != purpose || c.Subject == "" {
+		return nil, fmt.Errorf("%w: wrong token type", auth.ErrInvalidToken)
+	}
+
+	return &c, nil
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
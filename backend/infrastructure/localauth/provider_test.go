@@ -0,0 +1,221 @@
+package localauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+var testConfig = Config{Issuer: "https://club.example.com", Audience: "toolrentalclub", TokenTTL: time.Hour}
+
+// newKeySet returns a key set holding key under id
+func newKeySet(id string, method jwt.SigningMethod, key crypto.Signer) *KeySet {
+	k := &signingKey{id: id, method: method, signer: key}
+	return &KeySet{active: k, keys: map[string]*signingKey{id: k}}
+}
+
+func TestProviderSignVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		key    crypto.Signer
+	}{
+		{"RS256", jwt.SigningMethodRS256, newRSAKey(t, 2048)},
+		{"EdDSA", jwt.SigningMethodEdDSA, newEd25519Key(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := NewProvider(newKeySet("k1", tt.method, tt.key), testConfig)
+
+			issued, err := p.IssueAccessToken(ctx, "u1", "dora@example.com")
+			if err != nil {
+				t.Fatalf("IssueAccessToken() = %v", err)
+			}
+
+			token, _, err := jwt.NewParser().ParseUnverified(issued.Value, &claims{})
+			if err != nil {
+				t.Fatalf("ParseUnverified() = %v", err)
+			}
+			if token.Header["alg"] != tt.method.Alg() || token.Header["kid"] != "k1" {
+				t.Errorf("header = %v, want alg %s and kid k1", token.Header, tt.method.Alg())
+			}
+
+			verified, err := p.VerifyToken(ctx, issued.Value)
+			if err != nil {
+				t.Fatalf("VerifyToken() = %v", err)
+			}
+			if verified.UserID != "u1" || verified.Email != "dora@example.com" {
+				t.Errorf("VerifyToken() = %+v, want u1 with dora@example.com", verified)
+			}
+			if !verified.ExpiresAt.Equal(issued.ExpiresAt.Truncate(time.Second)) {
+				t.Errorf("ExpiresAt = %v, want %v", verified.ExpiresAt, issued.ExpiresAt)
+			}
+
+			if err := p.Check(ctx); err != nil {
+				t.Errorf("Check() = %v", err)
+			}
+		})
+	}
+}
+
+// TestProviderKeyRotation signs a token, rotates in a new active key and
+// checks the token still verifies by its kid until the old key is removed
+func TestProviderKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01", newRSAKey(t, 2048))
+
+	load := func() *Provider {
+		keys, err := LoadKeySet(dir, "")
+		if err != nil {
+			t.Fatalf("LoadKeySet() = %v", err)
+		}
+		return NewProvider(keys, testConfig)
+	}
+
+	old, err := load().IssueAccessToken(ctx, "u1", "")
+	if err != nil {
+		t.Fatalf("IssueAccessToken() = %v", err)
+	}
+
+	writeKey(t, dir, "2026-10", newEd25519Key(t))
+	rotated := load()
+
+	if _, err := rotated.VerifyToken(ctx, old.Value); err != nil {
+		t.Errorf("VerifyToken() of a token signed before the rotation = %v", err)
+	}
+
+	issued, err := rotated.IssueAccessToken(ctx, "u1", "")
+	if err != nil {
+		t.Fatalf("IssueAccessToken() = %v", err)
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(issued.Value, &claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() = %v", err)
+	}
+	if token.Header["kid"] != "2026-10" {
+		t.Errorf("kid = %v, want the new key 2026-10", token.Header["kid"])
+	}
+
+	if err := os.Remove(filepath.Join(dir, "2026-01.pem")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := load().VerifyToken(ctx, old.Value); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("VerifyToken() after the old key was removed = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestProviderRejectsTokens(t *testing.T) {
+	ctx := context.Background()
+	rsaKey := newRSAKey(t, 2048)
+	edKey := newEd25519Key(t)
+
+	keys := newKeySet("ed", jwt.SigningMethodEdDSA, edKey)
+	keys.keys["rsa"] = &signingKey{id: "rsa", method: jwt.SigningMethodRS256, signer: rsaKey}
+	p := NewProvider(keys, testConfig)
+
+	// sign signs access claims for u1 with key, overriding the defaults with change
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, change func(*claims)) string {
+		now := time.Now()
+		c := claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    testConfig.Issuer,
+				Subject:   "u1",
+				Audience:  jwt.ClaimStrings{testConfig.Audience},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+			Purpose: purposeAccess,
+		}
+		if change != nil {
+			change(&c)
+		}
+
+		token := jwt.NewWithClaims(method, c)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() = %v", err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"RS256 token under the kid of the EdDSA key", sign(jwt.SigningMethodRS256, "ed", rsaKey, nil)},
+		{"EdDSA token under the kid of the RS256 key", sign(jwt.SigningMethodEdDSA, "rsa", edKey, nil)},
+		{"HS256 token keyed with the public key", sign(jwt.SigningMethodHS256, "ed", []byte(edKey.Public().(ed25519.PublicKey)), nil)},
+		{"unknown kid", sign(jwt.SigningMethodEdDSA, "other", edKey, nil)},
+		{"signed by another key", sign(jwt.SigningMethodEdDSA, "ed", newEd25519Key(t), nil)},
+		{"expired", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) {
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		})},
+		{"no expiry", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.ExpiresAt = nil })},
+		{"other issuer", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.Issuer = "https://evil.example.com" })},
+		{"other audience", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.Audience = jwt.ClaimStrings{"other"} })},
+		{"no subject", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.Subject = "" })},
+		{"password reset token", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.Purpose = purposePasswordReset })},
+		{"no purpose", sign(jwt.SigningMethodEdDSA, "ed", edKey, func(c *claims) { c.Purpose = "" })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := p.VerifyToken(ctx, tt.token); !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("VerifyToken() = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		for _, token := range []string{sign(jwt.SigningMethodEdDSA, "ed", edKey, nil), sign(jwt.SigningMethodRS256, "rsa", rsaKey, nil)} {
+			if _, err := p.VerifyToken(ctx, token); err != nil {
+				t.Errorf("VerifyToken() = %v", err)
+			}
+		}
+	})
+}
+
+// TestProviderTokenPurpose checks that access and password reset tokens are
+// only accepted for their own purpose
+func TestProviderTokenPurpose(t *testing.T) {
+	ctx := context.Background()
+	p := NewProvider(newKeySet("k1", jwt.SigningMethodEdDSA, newEd25519Key(t)), testConfig)
+
+	reset, err := p.IssueResetToken(ctx, "u1", "fingerprint")
+	if err != nil {
+		t.Fatalf("IssueResetToken() = %v", err)
+	}
+	if _, err := p.VerifyToken(ctx, reset.Value); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("VerifyToken() of a reset token = %v, want ErrInvalidToken", err)
+	}
+
+	userID, fingerprint, err := p.VerifyResetToken(ctx, reset.Value)
+	if err != nil {
+		t.Fatalf("VerifyResetToken() = %v", err)
+	}
+	if userID != "u1" || fingerprint != "fingerprint" {
+		t.Errorf("VerifyResetToken() = %q, %q, want u1, fingerprint", userID, fingerprint)
+	}
+	if got := time.Until(reset.ExpiresAt); got > resetTokenTTL {
+		t.Errorf("reset token expires in %v, want at most %v", got, resetTokenTTL)
+	}
+
+	access, err := p.IssueAccessToken(ctx, "u1", "dora@example.com")
+	if err != nil {
+		t.Fatalf("IssueAccessToken() = %v", err)
+	}
+	if _, _, err := p.VerifyResetToken(ctx, access.Value); !errors.Is(err, auth.ErrInvalidResetToken) {
+		t.Errorf("VerifyResetToken() of an access token = %v, want ErrInvalidResetToken", err)
+	}
+}
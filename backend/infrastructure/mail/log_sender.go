@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+	"log"
+
+	"github.com/yourusername/toolrentalclub/domain/mail"
+)
+
+// LogSender implements mail.Sender by writing messages to the log.
+// It is meant for development when no SMTP server is configured.
+type LogSender struct{}
+
+// NewLogSender creates a new log-only mail sender
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send logs the message instead of delivering it
+func (s *LogSender) Send(ctx context.Context, msg mail.Message) error {
+	log.Printf("MAIL (not sent) to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package firestore implements the domain repositories on top of Cloud
+// Firestore, sharing the Firebase app used for authentication.
+//
+// Set FIRESTORE_EMULATOR_HOST to run against the Firestore emulator.
+package firestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	firebase "firebase.google.com/go/v4"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Collection names
+const (
+	usersCollection       = "users"
+	userEmailsCollection  = "userEmails"
+	toolsCollection       = "tools"
+	toolBlocksCollection  = "toolBlocks"
+	rentalsCollection     = "rentals"
+	rentalLocksCollection = "rentalLocks"
+	credentialsCollection = "credentials"
+	loginEmailsCollection = "loginEmails"
+)
+
+// NewClient creates a Firestore client from the Firebase app
+func NewClient(ctx context.Context, app *firebase.App) (*firestore.Client, error) {
+	if app == nil {
+		return nil, fmt.Errorf("firebase app not initialized")
+	}
+
+	client, err := app.Firestore(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firestore client: %w", err)
+	}
+
+	return client, nil
+}
+
+// Ping reports whether Firestore can be reached by reading at most one user
+func Ping(ctx context.Context, client *firestore.Client) error {
+	_, err := client.Collection(usersCollection).Limit(1).Documents(ctx).Next()
+	if err != nil && err != iterator.Done {
+		return fmt.Errorf("failed to reach firestore: %w", err)
+	}
+	return nil
+}
+
+// isNotFound reports whether err means the document does not exist
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// isAlreadyExists reports whether err means the document already exists
+func isAlreadyExists(err error) bool {
+	return status.Code(err) == codes.AlreadyExists
+}
+
+// emailKey derives a document ID from an email address, which may contain
+// characters that are not allowed in document IDs
+func emailKey(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+// replaceExisting overwrites a document inside a transaction, returning
+// notFound if it does not exist
+func replaceExisting(ctx context.Context, client *firestore.Client, ref *firestore.DocumentRef, data any, notFound error) error {
+	return client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(ref); err != nil {
+			if isNotFound(err) {
+				return notFound
+			}
+			return err
+		}
+		return tx.Set(ref, data)
+	})
+}
+
+// deleteExisting deletes a document, returning notFound if it does not exist
+func deleteExisting(ctx context.Context, ref *firestore.DocumentRef, notFound error) error {
+	_, err := ref.Delete(ctx, firestore.Exists)
+	if isNotFound(err) {
+		return notFound
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package firestore
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+// projects numbers the emulator projects created by newTestClient
+var projects atomic.Int64
+
+// newTestClient connects to the Firestore emulator at FIRESTORE_EMULATOR_HOST
+// with a project of its own, so every test starts from an empty database.
+// Tests that need the emulator are skipped when FIRESTORE_EMULATOR_HOST is
+// unset; to run them:
+//
+//	firebase emulators:start --only firestore
+//	FIRESTORE_EMULATOR_HOST=localhost:8080 go test ./infrastructure/repository/firestore
+func newTestClient(t *testing.T) *firestore.Client {
+	t.Helper()
+
+	if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+		t.Skip("FIRESTORE_EMULATOR_HOST is not set")
+	}
+
+	projectID := "test-" + strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(projects.Add(1), 10)
+	client, err := firestore.NewClient(context.Background(), projectID)
+	if err != nil {
+		t.Fatalf("firestore.NewClient() = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
@@ -0,0 +1,177 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+// CredentialRepository implements auth.CredentialRepository interface using
+// Firestore. Like UserRepository, login emails are kept unique by an index
+// collection written in the same transaction as the credential.
+type CredentialRepository struct {
+	client *firestore.Client
+}
+
+// NewCredentialRepository creates a new Firestore credential repository
+func NewCredentialRepository(client *firestore.Client) *CredentialRepository {
+	return &CredentialRepository{client: client}
+}
+
+type credentialDoc struct {
+	Email        string    `firestore:"email"`
+	PasswordHash string    `firestore:"passwordHash"`
+	CreatedAt    time.Time `firestore:"createdAt"`
+	UpdatedAt    time.Time `firestore:"updatedAt"`
+}
+
+// FindByUserID retrieves the credential of a user
+func (r *CredentialRepository) FindByUserID(ctx context.Context, userID string) (*auth.Credential, error) {
+	snap, err := r.credentials().Doc(userID).Get(ctx)
+	if isNotFound(err) {
+		return nil, auth.ErrCredentialNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential: %w", err)
+	}
+
+	return toCredential(snap)
+}
+
+// FindByEmail retrieves a credential by login email
+func (r *CredentialRepository) FindByEmail(ctx context.Context, email string) (*auth.Credential, error) {
+	snap, err := r.emails().Doc(emailKey(email)).Get(ctx)
+	if isNotFound(err) {
+		return nil, auth.ErrCredentialNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential: %w", err)
+	}
+
+	var index userEmailDoc
+	if err := snap.DataTo(&index); err != nil {
+		return nil, fmt.Errorf("failed to load credential: %w", err)
+	}
+
+	return r.FindByUserID(ctx, index.UserID)
+}
+
+// Create creates a new credential
+func (r *CredentialRepository) Create(ctx context.Context, c *auth.Credential) error {
+	credentialRef := r.credentials().Doc(c.UserID)
+	emailRef := r.emails().Doc(emailKey(c.Email))
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, ref := range []*firestore.DocumentRef{credentialRef, emailRef} {
+			if _, err := tx.Get(ref); err == nil {
+				return auth.ErrEmailTaken
+			} else if !isNotFound(err) {
+				return err
+			}
+		}
+
+		if err := tx.Create(emailRef, userEmailDoc{UserID: c.UserID}); err != nil {
+			return err
+		}
+		return tx.Create(credentialRef, toCredentialDoc(c))
+	})
+}
+
+// Update updates an existing credential
+func (r *CredentialRepository) Update(ctx context.Context, c *auth.Credential) error {
+	credentialRef := r.credentials().Doc(c.UserID)
+	newEmailRef := r.emails().Doc(emailKey(c.Email))
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(credentialRef)
+		if isNotFound(err) {
+			return auth.ErrCredentialNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := toCredential(snap)
+		if err != nil {
+			return err
+		}
+
+		// If email changed, move the index entry
+		if existing.Email != c.Email {
+			if _, err := tx.Get(newEmailRef); err == nil {
+				return auth.ErrEmailTaken
+			} else if !isNotFound(err) {
+				return err
+			}
+			if err := tx.Create(newEmailRef, userEmailDoc{UserID: c.UserID}); err != nil {
+				return err
+			}
+			if err := tx.Delete(r.emails().Doc(emailKey(existing.Email))); err != nil {
+				return err
+			}
+		}
+
+		return tx.Set(credentialRef, toCredentialDoc(c))
+	})
+}
+
+// Delete removes the credential of a user, together with their login email index entry
+func (r *CredentialRepository) Delete(ctx context.Context, userID string) error {
+	credentialRef := r.credentials().Doc(userID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(credentialRef)
+		if isNotFound(err) {
+			return auth.ErrCredentialNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := toCredential(snap)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(r.emails().Doc(emailKey(existing.Email))); err != nil {
+			return err
+		}
+		return tx.Delete(credentialRef)
+	})
+}
+
+func (r *CredentialRepository) credentials() *firestore.CollectionRef {
+	return r.client.Collection(credentialsCollection)
+}
+
+func (r *CredentialRepository) emails() *firestore.CollectionRef {
+	return r.client.Collection(loginEmailsCollection)
+}
+
+func toCredentialDoc(c *auth.Credential) credentialDoc {
+	return credentialDoc{
+		Email:        c.Email,
+		PasswordHash: c.PasswordHash,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+func toCredential(snap *firestore.DocumentSnapshot) (*auth.Credential, error) {
+	var doc credentialDoc
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode credential: %w", err)
+	}
+
+	return &auth.Credential{
+		UserID:       snap.Ref.ID,
+		Email:        doc.Email,
+		PasswordHash: doc.PasswordHash,
+		CreatedAt:    doc.CreatedAt,
+		UpdatedAt:    doc.UpdatedAt,
+	}, nil
+}
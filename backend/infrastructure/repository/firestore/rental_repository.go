@@ -0,0 +1,233 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+)
+
+// RentalRepository implements rental.Repository interface using Firestore.
+//
+// Queries inside a transaction do not lock documents that do not exist yet,
+// so writes also read and touch a per-tool lock document. Two concurrent
+// bookings of the same tool therefore contend on that document and one of
+// them is retried, seeing the other's rental when it re-checks for conflicts.
+// ToolBlockRepository.Create touches the same document, so a new block also
+// makes a concurrent booking of the tool retry and see the block.
+type RentalRepository struct {
+	client *firestore.Client
+}
+
+// NewRentalRepository creates a new Firestore rental repository
+func NewRentalRepository(client *firestore.Client) *RentalRepository {
+	return &RentalRepository{client: client}
+}
+
+type rentalDoc struct {
+	ToolID    string    `firestore:"toolId"`
+	RenterID  string    `firestore:"renterId"`
+	Start     time.Time `firestore:"start"`
+	End       time.Time `firestore:"end"`
+	Status    string    `firestore:"status"`
+	CreatedAt time.Time `firestore:"createdAt"`
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+type rentalLockDoc struct {
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+// FindByID retrieves a rental by its ID
+func (r *RentalRepository) FindByID(ctx context.Context, id string) (*rental.Rental, error) {
+	snap, err := r.rentals().Doc(id).Get(ctx)
+	if isNotFound(err) {
+		return nil, rental.ErrRentalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rental: %w", err)
+	}
+
+	return toRental(snap)
+}
+
+// FindByToolID retrieves every rental of a tool, ordered by start date
+func (r *RentalRepository) FindByToolID(ctx context.Context, toolID string) ([]*rental.Rental, error) {
+	snaps, err := r.rentals().Where("toolId", "==", toolID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rentals: %w", err)
+	}
+
+	return toRentals(snaps)
+}
+
+// FindByRenterID retrieves every rental made by a user, ordered by start date
+func (r *RentalRepository) FindByRenterID(ctx context.Context, renterID string) ([]*rental.Rental, error) {
+	snaps, err := r.rentals().Where("renterId", "==", renterID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rentals: %w", err)
+	}
+
+	return toRentals(snaps)
+}
+
+// FindOverdue retrieves every rental that is overdue at now, ordered by end date
+func (r *RentalRepository) FindOverdue(ctx context.Context, now time.Time) ([]*rental.Rental, error) {
+	snaps, err := r.rentals().
+		Where("status", "==", string(rental.StatusPickedUp)).
+		Where("end", "<=", now).
+		OrderBy("end", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue rentals: %w", err)
+	}
+
+	return toRentals(snaps)
+}
+
+// Create creates a new rental
+func (r *RentalRepository) Create(ctx context.Context, rent *rental.Rental) error {
+	ref := r.rentals().Doc(rent.ID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(ref); err == nil {
+			return rental.ErrRentalExists
+		} else if !isNotFound(err) {
+			return err
+		}
+
+		if err := r.checkConflicts(tx, rent, ""); err != nil {
+			return err
+		}
+
+		return tx.Create(ref, toRentalDoc(rent))
+	})
+}
+
+// Update updates an existing rental
+func (r *RentalRepository) Update(ctx context.Context, rent *rental.Rental, expected rental.Status) error {
+	ref := r.rentals().Doc(rent.ID)
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(ref)
+		if isNotFound(err) {
+			return rental.ErrRentalNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		stored, err := toRental(snap)
+		if err != nil {
+			return err
+		}
+		if stored.Status != expected {
+			return fmt.Errorf("%w: rental was changed concurrently", rental.ErrInvalidTransition)
+		}
+
+		if err := r.checkConflicts(tx, rent, stored.Status); err != nil {
+			return err
+		}
+
+		return tx.Set(ref, toRentalDoc(rent))
+	})
+}
+
+// checkConflicts claims the tool's lock document and rejects the rental if it
+// overlaps a blocking rental or a block of the same tool; previous is the
+// status the rental is stored with, empty for a new rental. All reads happen
+// before the lock document is written, as Firestore transactions require.
+func (r *RentalRepository) checkConflicts(tx *firestore.Transaction, rent *rental.Rental, previous rental.Status) error {
+	lockRef := r.client.Collection(rentalLocksCollection).Doc(rent.ToolID)
+	if _, err := tx.Get(lockRef); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	snaps, err := tx.Documents(r.rentals().Where("toolId", "==", rent.ToolID)).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to check rental conflicts: %w", err)
+	}
+
+	existing, err := toRentals(snaps)
+	if err != nil {
+		return err
+	}
+
+	if err := rent.CheckConflicts(existing, previous == ""); err != nil {
+		return err
+	}
+
+	snaps, err = tx.Documents(r.client.Collection(toolBlocksCollection).Where("toolId", "==", rent.ToolID)).GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to check tool blocks: %w", err)
+	}
+
+	blocks, err := toBlocks(snaps)
+	if err != nil {
+		return err
+	}
+
+	if err := rent.CheckBlocks(blocks, previous); err != nil {
+		return err
+	}
+
+	return tx.Set(lockRef, rentalLockDoc{UpdatedAt: time.Now()})
+}
+
+func (r *RentalRepository) rentals() *firestore.CollectionRef {
+	return r.client.Collection(rentalsCollection)
+}
+
+func toRentalDoc(rent *rental.Rental) rentalDoc {
+	return rentalDoc{
+		ToolID:    rent.ToolID,
+		RenterID:  rent.RenterID,
+		Start:     rent.Start,
+		End:       rent.End,
+		Status:    string(rent.Status),
+		CreatedAt: rent.CreatedAt,
+		UpdatedAt: rent.UpdatedAt,
+	}
+}
+
+func toRentals(snaps []*firestore.DocumentSnapshot) ([]*rental.Rental, error) {
+	rentals := make([]*rental.Rental, 0, len(snaps))
+	for _, snap := range snaps {
+		rent, err := toRental(snap)
+		if err != nil {
+			return nil, err
+		}
+		rentals = append(rentals, rent)
+	}
+
+	sort.Slice(rentals, func(i, j int) bool {
+		if rentals[i].Start.Equal(rentals[j].Start) {
+			return rentals[i].ID < rentals[j].ID
+		}
+		return rentals[i].Start.Before(rentals[j].Start)
+	})
+
+	return rentals, nil
+}
+
+func toRental(snap *firestore.DocumentSnapshot) (*rental.Rental, error) {
+	var doc rentalDoc
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode rental: %w", err)
+	}
+
+	return &rental.Rental{
+		ID:        snap.Ref.ID,
+		ToolID:    doc.ToolID,
+		RenterID:  doc.RenterID,
+		Start:     doc.Start,
+		End:       doc.End,
+		Status:    rental.Status(doc.Status),
+		CreatedAt: doc.CreatedAt,
+		UpdatedAt: doc.UpdatedAt,
+	}, nil
+}
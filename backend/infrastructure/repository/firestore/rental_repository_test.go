@@ -0,0 +1,116 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// day returns midnight UTC of the given day in January 2030
+func day(d int) time.Time {
+	return time.Date(2030, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+// TestRentalRepositoryConcurrentCreates stores overlapping confirmed rentals
+// of the same tool at once; the transactions contend on the tool's lock
+// document, so exactly one of them may go through
+func TestRentalRepositoryConcurrentCreates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRentalRepository(newTestClient(t))
+
+	const rentals = 5
+	var wg sync.WaitGroup
+	errs := make([]error, rentals)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rent := rental.NewRental(fmt.Sprintf("r%d", i), "drill", "renter", day(1+i%3), day(5+i%3))
+			rent.Status = rental.StatusConfirmed
+			errs[i] = repo.Create(ctx, rent)
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, rental.ErrConflict):
+			t.Errorf("Create(r%d) = %v, want nil or ErrConflict", i, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d overlapping rentals created, want exactly 1", created)
+	}
+}
+
+func TestRentalRepositoryConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     rental.Status
+		expected rental.Status
+		other    *rental.Rental // a confirmed rental stored first, if any
+		want     error
+	}{
+		{"confirming next to confirmed", rental.StatusConfirmed, rental.StatusRequested, rental.NewRental("r2", "drill", "renter", day(5), day(9)), nil},
+		{"confirming over confirmed", rental.StatusConfirmed, rental.StatusRequested, rental.NewRental("r2", "drill", "renter", day(4), day(9)), rental.ErrConflict},
+		{"confirming over another tool's rental", rental.StatusConfirmed, rental.StatusRequested, rental.NewRental("r2", "saw", "renter", day(1), day(5)), nil},
+		{"cancelling over confirmed", rental.StatusCancelled, rental.StatusRequested, rental.NewRental("r2", "drill", "renter", day(4), day(9)), nil},
+		{"changed concurrently", rental.StatusConfirmed, rental.StatusConfirmed, nil, rental.ErrInvalidTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewRentalRepository(newTestClient(t))
+
+			if tt.other != nil {
+				tt.other.Status = rental.StatusConfirmed
+				if err := repo.Create(ctx, tt.other); err != nil {
+					t.Fatalf("Create(other) = %v", err)
+				}
+			}
+			rent := rental.NewRental("r1", "drill", "renter", day(1), day(5))
+			if err := repo.Create(ctx, rent); err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+
+			rent.Status = tt.next
+			if err := repo.Update(ctx, rent, tt.expected); !errors.Is(err, tt.want) {
+				t.Errorf("Update() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalRepositoryBlocks(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	repo := NewRentalRepository(client)
+
+	requested := rental.NewRental("requested", "drill", "renter", day(1), day(5))
+	if err := repo.Create(ctx, requested); err != nil {
+		t.Fatalf("Create(requested) = %v", err)
+	}
+
+	block := tool.NewBlock("b1", "drill", tool.BlockMaintenance, day(3), day(6), "")
+	if err := NewToolBlockRepository(client).Create(ctx, block); err != nil {
+		t.Fatalf("Create(block) = %v", err)
+	}
+
+	if err := repo.Create(ctx, rental.NewRental("new", "drill", "renter", day(2), day(4))); !errors.Is(err, rental.ErrToolBlocked) {
+		t.Errorf("Create() over the block = %v, want ErrToolBlocked", err)
+	}
+
+	requested.Status = rental.StatusConfirmed
+	if err := repo.Update(ctx, requested, rental.StatusRequested); !errors.Is(err, rental.ErrToolBlocked) {
+		t.Errorf("Update() confirming over the block = %v, want ErrToolBlocked", err)
+	}
+}
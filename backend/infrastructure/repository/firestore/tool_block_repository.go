@@ -0,0 +1,111 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolBlockRepository implements tool.BlockRepository interface using Firestore
+type ToolBlockRepository struct {
+	client *firestore.Client
+}
+
+// NewToolBlockRepository creates a new Firestore tool block repository
+func NewToolBlockRepository(client *firestore.Client) *ToolBlockRepository {
+	return &ToolBlockRepository{client: client}
+}
+
+type toolBlockDoc struct {
+	ToolID    string    `firestore:"toolId"`
+	Kind      string    `firestore:"kind"`
+	Start     time.Time `firestore:"start"`
+	End       time.Time `firestore:"end"`
+	Reason    string    `firestore:"reason"`
+	CreatedAt time.Time `firestore:"createdAt"`
+}
+
+// FindByToolID retrieves every block of a tool, ordered by start date
+func (r *ToolBlockRepository) FindByToolID(ctx context.Context, toolID string) ([]*tool.Block, error) {
+	// Sorting happens client-side so the query needs no composite index
+	snaps, err := r.blocks().Where("toolId", "==", toolID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool blocks: %w", err)
+	}
+
+	return toBlocks(snaps)
+}
+
+// Create creates a new block. It touches the tool's rental lock document,
+// so bookings of the tool that are being stored retry and see the block
+// (see RentalRepository).
+func (r *ToolBlockRepository) Create(ctx context.Context, b *tool.Block) error {
+	ref := r.blocks().Doc(b.ID)
+	lockRef := r.client.Collection(rentalLocksCollection).Doc(b.ToolID)
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(lockRef); err != nil && !isNotFound(err) {
+			return err
+		}
+
+		if err := tx.Create(ref, toolBlockDoc{
+			ToolID:    b.ToolID,
+			Kind:      string(b.Kind),
+			Start:     b.Start,
+			End:       b.End,
+			Reason:    b.Reason,
+			CreatedAt: b.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Set(lockRef, rentalLockDoc{UpdatedAt: time.Now()})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tool block: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a block by its ID
+func (r *ToolBlockRepository) Delete(ctx context.Context, id string) error {
+	return deleteExisting(ctx, r.blocks().Doc(id), tool.ErrBlockNotFound)
+}
+
+func (r *ToolBlockRepository) blocks() *firestore.CollectionRef {
+	return r.client.Collection(toolBlocksCollection)
+}
+
+func toBlocks(snaps []*firestore.DocumentSnapshot) ([]*tool.Block, error) {
+	blocks := make([]*tool.Block, 0, len(snaps))
+	for _, snap := range snaps {
+		var doc toolBlockDoc
+		if err := snap.DataTo(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode tool block: %w", err)
+		}
+		blocks = append(blocks, &tool.Block{
+			ID:        snap.Ref.ID,
+			ToolID:    doc.ToolID,
+			Kind:      tool.BlockKind(doc.Kind),
+			Start:     doc.Start,
+			End:       doc.End,
+			Reason:    doc.Reason,
+			CreatedAt: doc.CreatedAt,
+		})
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].Start.Equal(blocks[j].Start) {
+			return blocks[i].ID < blocks[j].ID
+		}
+		return blocks[i].Start.Before(blocks[j].Start)
+	})
+
+	return blocks, nil
+}
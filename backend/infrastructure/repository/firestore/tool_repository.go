@@ -0,0 +1,137 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolRepository implements tool.Repository interface using Firestore
+type ToolRepository struct {
+	client *firestore.Client
+}
+
+// NewToolRepository creates a new Firestore tool repository
+func NewToolRepository(client *firestore.Client) *ToolRepository {
+	return &ToolRepository{client: client}
+}
+
+type toolDoc struct {
+	Name                  string    `firestore:"name"`
+	Description           string    `firestore:"description"`
+	Category              string    `firestore:"category"`
+	Condition             string    `firestore:"condition"`
+	ReplacementValueCents int64     `firestore:"replacementValueCents"`
+	DailyRateCents        int64     `firestore:"dailyRateCents"`
+	OwnerID               string    `firestore:"ownerId"`
+	Status                string    `firestore:"status"`
+	CreatedAt             time.Time `firestore:"createdAt"`
+	UpdatedAt             time.Time `firestore:"updatedAt"`
+}
+
+// FindByID retrieves a tool by its ID
+func (r *ToolRepository) FindByID(ctx context.Context, id string) (*tool.Tool, error) {
+	snap, err := r.tools().Doc(id).Get(ctx)
+	if isNotFound(err) {
+		return nil, tool.ErrToolNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tool: %w", err)
+	}
+
+	return toTool(snap)
+}
+
+// FindAll retrieves every tool, oldest first
+func (r *ToolRepository) FindAll(ctx context.Context) ([]*tool.Tool, error) {
+	snaps, err := r.tools().Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+
+	tools := make([]*tool.Tool, 0, len(snaps))
+	for _, snap := range snaps {
+		t, err := toTool(snap)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, t)
+	}
+
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].CreatedAt.Equal(tools[j].CreatedAt) {
+			return tools[i].ID < tools[j].ID
+		}
+		return tools[i].CreatedAt.Before(tools[j].CreatedAt)
+	})
+
+	return tools, nil
+}
+
+// Create creates a new tool
+func (r *ToolRepository) Create(ctx context.Context, t *tool.Tool) error {
+	_, err := r.tools().Doc(t.ID).Create(ctx, toToolDoc(t))
+	if isAlreadyExists(err) {
+		return tool.ErrToolExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create tool: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing tool
+func (r *ToolRepository) Update(ctx context.Context, t *tool.Tool) error {
+	return replaceExisting(ctx, r.client, r.tools().Doc(t.ID), toToolDoc(t), tool.ErrToolNotFound)
+}
+
+// Delete removes a tool by its ID
+func (r *ToolRepository) Delete(ctx context.Context, id string) error {
+	return deleteExisting(ctx, r.tools().Doc(id), tool.ErrToolNotFound)
+}
+
+func (r *ToolRepository) tools() *firestore.CollectionRef {
+	return r.client.Collection(toolsCollection)
+}
+
+func toToolDoc(t *tool.Tool) toolDoc {
+	return toolDoc{
+		Name:                  t.Name,
+		Description:           t.Description,
+		Category:              string(t.Category),
+		Condition:             string(t.Condition),
+		ReplacementValueCents: t.ReplacementValueCents,
+		DailyRateCents:        t.DailyRateCents,
+		OwnerID:               t.OwnerID,
+		Status:                string(t.Status),
+		CreatedAt:             t.CreatedAt,
+		UpdatedAt:             t.UpdatedAt,
+	}
+}
+
+func toTool(snap *firestore.DocumentSnapshot) (*tool.Tool, error) {
+	var doc toolDoc
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode tool: %w", err)
+	}
+
+	return &tool.Tool{
+		ID:                    snap.Ref.ID,
+		Name:                  doc.Name,
+		Description:           doc.Description,
+		Category:              tool.Category(doc.Category),
+		Condition:             tool.Condition(doc.Condition),
+		ReplacementValueCents: doc.ReplacementValueCents,
+		DailyRateCents:        doc.DailyRateCents,
+		OwnerID:               doc.OwnerID,
+		Status:                tool.Status(doc.Status),
+		CreatedAt:             doc.CreatedAt,
+		UpdatedAt:             doc.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,347 @@
+package firestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// UserRepository implements user.Repository interface using Firestore.
+// Email uniqueness is enforced by an index collection keyed by email whose
+// documents are created in the same transaction as the user, so the
+// invariant holds across instances.
+type UserRepository struct {
+	client *firestore.Client
+}
+
+// NewUserRepository creates a new Firestore user repository
+func NewUserRepository(client *firestore.Client) *UserRepository {
+	return &UserRepository{client: client}
+}
+
+// userDoc is the stored form of a user. Users stored before account
+// statuses existed have no status and are active.
+type userDoc struct {
+	Email          string      `firestore:"email"`
+	EmailVerified  bool        `firestore:"emailVerified"`
+	Roles          []string    `firestore:"roles"`
+	Profile        *profileDoc `firestore:"profile"`
+	Status         string      `firestore:"status,omitempty"`
+	StatusReason   string      `firestore:"statusReason,omitempty"`
+	SuspendedUntil *time.Time  `firestore:"suspendedUntil,omitempty"`
+	CreatedAt      time.Time   `firestore:"createdAt"`
+	UpdatedAt      time.Time   `firestore:"updatedAt"`
+}
+
+// profileDoc is stored inside the user document. Users created before
+// profiles existed have none and get the defaults of a new user.
+type profileDoc struct {
+	DisplayName   string          `firestore:"displayName"`
+	Phone         string          `firestore:"phone"`
+	Address       addressDoc      `firestore:"address"`
+	AvatarURL     string          `firestore:"avatarUrl"`
+	Bio           string          `firestore:"bio"`
+	Notifications notificationDoc `firestore:"notifications"`
+}
+
+type addressDoc struct {
+	Line1      string `firestore:"line1"`
+	Line2      string `firestore:"line2"`
+	City       string `firestore:"city"`
+	Region     string `firestore:"region"`
+	PostalCode string `firestore:"postalCode"`
+	Country    string `firestore:"country"`
+}
+
+type notificationDoc struct {
+	RentalUpdates bool `firestore:"rentalUpdates"`
+	Reminders     bool `firestore:"reminders"`
+	SMS           bool `firestore:"sms"`
+	Newsletter    bool `firestore:"newsletter"`
+}
+
+type userEmailDoc struct {
+	UserID string `firestore:"userId"`
+}
+
+// FindByID retrieves a user by their ID
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	snap, err := r.users().Doc(id).Get(ctx)
+	if isNotFound(err) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	return toUser(snap)
+}
+
+// FindByEmail retrieves a user by their email
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	if email == "" {
+		return nil, user.ErrUserNotFound
+	}
+
+	snap, err := r.emails().Doc(emailKey(email)).Get(ctx)
+	if isNotFound(err) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	var index userEmailDoc
+	if err := snap.DataTo(&index); err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	return r.FindByID(ctx, index.UserID)
+}
+
+// Create creates a new user
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	userRef := r.users().Doc(u.ID)
+	emailRef := r.emails().Doc(emailKey(u.Email))
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(userRef); err == nil {
+			return user.ErrUserExists
+		} else if !isNotFound(err) {
+			return err
+		}
+
+		if u.Email != "" {
+			if _, err := tx.Get(emailRef); err == nil {
+				return user.ErrEmailTaken
+			} else if !isNotFound(err) {
+				return err
+			}
+			if err := tx.Create(emailRef, userEmailDoc{UserID: u.ID}); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(userRef, toUserDoc(u))
+	})
+}
+
+// Update updates an existing user
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	userRef := r.users().Doc(u.ID)
+	newEmailRef := r.emails().Doc(emailKey(u.Email))
+
+	return r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		snap, err := tx.Get(userRef)
+		if isNotFound(err) {
+			return user.ErrUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := toUser(snap)
+		if err != nil {
+			return err
+		}
+
+		// If email changed, move the index entry
+		if existing.Email != u.Email {
+			if u.Email != "" {
+				emailSnap, err := tx.Get(newEmailRef)
+				if err == nil {
+					var index userEmailDoc
+					if err := emailSnap.DataTo(&index); err != nil {
+						return err
+					}
+					if index.UserID != u.ID {
+						return user.ErrEmailTaken
+					}
+				} else if !isNotFound(err) {
+					return err
+				}
+				if err := tx.Set(newEmailRef, userEmailDoc{UserID: u.ID}); err != nil {
+					return err
+				}
+			}
+			if existing.Email != "" {
+				if err := tx.Delete(r.emails().Doc(emailKey(existing.Email))); err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Set(userRef, toUserDoc(u))
+	})
+}
+
+// List retrieves a page of the users matching the query. The sort order and
+// the cursor are part of the Firestore query, with the document ID breaking
+// ties, so a page only reads users from the cursor on and stops once it is
+// full. The filters are applied to the users as they are read: Firestore
+// cannot combine them with the sort order without a composite index for
+// every combination, nor leave out deleted users with a != filter on another
+// field than the one sorted by.
+func (r *UserRepository) List(ctx context.Context, query user.ListQuery) (*user.ListPage, error) {
+	cursor, err := query.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	field, direction := "createdAt", firestore.Asc
+	if query.Sort == user.SortByEmail {
+		field = "email"
+	}
+	if query.Descending {
+		direction = firestore.Desc
+	}
+
+	q := r.users().OrderBy(field, direction).OrderBy(firestore.DocumentID, direction)
+	if cursor != nil {
+		var key any = cursor.CreatedAt
+		if query.Sort == user.SortByEmail {
+			key = cursor.Email
+		}
+		q = q.StartAfter(key, cursor.ID)
+	}
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	page := &user.ListPage{Users: make([]*user.User, 0, query.Limit)}
+	for {
+		snap, err := iter.Next()
+		if err == iterator.Done {
+			return page, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+
+		u, err := toUser(snap)
+		if err != nil {
+			return nil, err
+		}
+		if !query.Matches(u) {
+			continue
+		}
+
+		if len(page.Users) == query.Limit {
+			page.NextCursor = query.CursorAfter(page.Users[query.Limit-1])
+			return page, nil
+		}
+		page.Users = append(page.Users, u)
+	}
+}
+
+func (r *UserRepository) users() *firestore.CollectionRef {
+	return r.client.Collection(usersCollection)
+}
+
+func (r *UserRepository) emails() *firestore.CollectionRef {
+	return r.client.Collection(userEmailsCollection)
+}
+
+func toUserDoc(u *user.User) userDoc {
+	roles := make([]string, len(u.Roles))
+	for i, r := range u.Roles {
+		roles[i] = string(r)
+	}
+
+	doc := userDoc{
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Roles:         roles,
+		Profile:       toProfileDoc(u.Profile),
+		Status:        string(u.Status),
+		StatusReason:  u.StatusReason,
+		CreatedAt:     u.CreatedAt,
+		UpdatedAt:     u.UpdatedAt,
+	}
+	if !u.SuspendedUntil.IsZero() {
+		until := u.SuspendedUntil
+		doc.SuspendedUntil = &until
+	}
+	return doc
+}
+
+func toUser(snap *firestore.DocumentSnapshot) (*user.User, error) {
+	var doc userDoc
+	if err := snap.DataTo(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	u := &user.User{
+		ID:            snap.Ref.ID,
+		Email:         doc.Email,
+		EmailVerified: doc.EmailVerified,
+		Roles:         user.ParseRoles(doc.Roles),
+		Profile:       toProfile(doc.Profile),
+		Status:        user.Status(doc.Status),
+		StatusReason:  doc.StatusReason,
+		CreatedAt:     doc.CreatedAt,
+		UpdatedAt:     doc.UpdatedAt,
+	}
+	if u.Status == "" {
+		u.Status = user.StatusActive
+	}
+	if doc.SuspendedUntil != nil {
+		u.SuspendedUntil = *doc.SuspendedUntil
+	}
+	return u, nil
+}
+
+func toProfileDoc(p user.Profile) *profileDoc {
+	return &profileDoc{
+		DisplayName: p.DisplayName,
+		Phone:       p.Phone,
+		Address: addressDoc{
+			Line1:      p.Address.Line1,
+			Line2:      p.Address.Line2,
+			City:       p.Address.City,
+			Region:     p.Address.Region,
+			PostalCode: p.Address.PostalCode,
+			Country:    p.Address.Country,
+		},
+		AvatarURL: p.AvatarURL,
+		Bio:       p.Bio,
+		Notifications: notificationDoc{
+			RentalUpdates: p.Notifications.RentalUpdates,
+			Reminders:     p.Notifications.Reminders,
+			SMS:           p.Notifications.SMS,
+			Newsletter:    p.Notifications.Newsletter,
+		},
+	}
+}
+
+func toProfile(doc *profileDoc) user.Profile {
+	if doc == nil {
+		return user.Profile{Notifications: user.DefaultNotificationPreferences()}
+	}
+
+	return user.Profile{
+		DisplayName: doc.DisplayName,
+		Phone:       doc.Phone,
+		Address: user.Address{
+			Line1:      doc.Address.Line1,
+			Line2:      doc.Address.Line2,
+			City:       doc.Address.City,
+			Region:     doc.Address.Region,
+			PostalCode: doc.Address.PostalCode,
+			Country:    doc.Address.Country,
+		},
+		AvatarURL: doc.AvatarURL,
+		Bio:       doc.Bio,
+		Notifications: user.NotificationPreferences{
+			RentalUpdates: doc.Notifications.RentalUpdates,
+			Reminders:     doc.Notifications.Reminders,
+			SMS:           doc.Notifications.SMS,
+			Newsletter:    doc.Notifications.Newsletter,
+		},
+	}
+}
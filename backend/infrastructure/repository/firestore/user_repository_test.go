@@ -0,0 +1,212 @@
+package firestore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// TestUserRepositoryEmailIndex checks that the userEmails index documents
+// follow every change to a user's email, and only those that succeed
+func TestUserRepositoryEmailIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, repo *UserRepository) error
+		want   error
+		index  map[string]string // email -> ID of the user its index document points to; "" for none
+	}{
+		{
+			name: "create with taken email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u3", "dora@example.com"))
+			},
+			want:  user.ErrEmailTaken,
+			index: map[string]string{"dora@example.com": "u1"},
+		},
+		{
+			name: "create with taken ID",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u1", "new@example.com"))
+			},
+			want:  user.ErrUserExists,
+			index: map[string]string{"new@example.com": "", "dora@example.com": "u1"},
+		},
+		{
+			name: "users without email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				if err := repo.Create(ctx, user.NewUser("u3", "")); err != nil {
+					return err
+				}
+				return repo.Create(ctx, user.NewUser("u4", ""))
+			},
+			index: map[string]string{"": ""},
+		},
+		{
+			name: "email changed",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, err := repo.FindByID(ctx, "u1")
+				if err != nil {
+					return err
+				}
+				u.Email = "dora@new.example.com"
+				return repo.Update(ctx, u)
+			},
+			index: map[string]string{"dora@example.com": "", "dora@new.example.com": "u1"},
+		},
+		{
+			name: "email changed to a taken one",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, err := repo.FindByID(ctx, "u1")
+				if err != nil {
+					return err
+				}
+				u.Email = "bob@example.com"
+				return repo.Update(ctx, u)
+			},
+			want:  user.ErrEmailTaken,
+			index: map[string]string{"dora@example.com": "u1", "bob@example.com": "u2"},
+		},
+		{
+			name: "email cleared",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, err := repo.FindByID(ctx, "u1")
+				if err != nil {
+					return err
+				}
+				u.Email = ""
+				return repo.Update(ctx, u)
+			},
+			index: map[string]string{"dora@example.com": ""},
+		},
+		{
+			name: "missing user",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Update(ctx, user.NewUser("u3", "carol@example.com"))
+			},
+			want:  user.ErrUserNotFound,
+			index: map[string]string{"carol@example.com": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewUserRepository(newTestClient(t))
+			for _, u := range []*user.User{user.NewUser("u1", "dora@example.com"), user.NewUser("u2", "bob@example.com")} {
+				if err := repo.Create(ctx, u); err != nil {
+					t.Fatalf("Create() = %v", err)
+				}
+			}
+
+			if err := tt.change(ctx, repo); !errors.Is(err, tt.want) {
+				t.Fatalf("change returned %v, want %v", err, tt.want)
+			}
+
+			for email, id := range tt.index {
+				snap, err := repo.emails().Doc(emailKey(email)).Get(ctx)
+				if id == "" {
+					if !isNotFound(err) {
+						t.Errorf("index document of %q exists (error %v), want none", email, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("loading the index document of %q: %v", email, err)
+				}
+
+				var index userEmailDoc
+				if err := snap.DataTo(&index); err != nil {
+					t.Fatalf("decoding the index document of %q: %v", email, err)
+				}
+				if index.UserID != id {
+					t.Errorf("index document of %q points to %q, want %q", email, index.UserID, id)
+				}
+			}
+		})
+	}
+}
+
+// TestUserRepositoryList pages through users created a day apart, with an
+// email ordering that differs from their creation order, and checks the
+// listing matches the in-memory keyset pagination of user.Paginate
+func TestUserRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(newTestClient(t))
+
+	base := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	users := []*user.User{
+		{ID: "a", Email: "dora@example.com", CreatedAt: base, Status: user.StatusActive},
+		{ID: "b", Email: "bob@example.com", CreatedAt: base.Add(24 * time.Hour), Status: user.StatusActive, Roles: []user.Role{user.RoleStaff}},
+		{ID: "c", Email: "carol@example.com", CreatedAt: base.Add(48 * time.Hour), Status: user.StatusSuspended},
+		{ID: "d", Email: "alice@example.com", CreatedAt: base.Add(48 * time.Hour), Status: user.StatusActive},
+		{ID: "e", Email: "erased@example.com", CreatedAt: base.Add(72 * time.Hour), Status: user.StatusDeleted},
+	}
+	for _, u := range users {
+		u.UpdatedAt = u.CreatedAt
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create(%s) = %v", u.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query user.ListQuery
+		count int // users listed
+	}{
+		{"created ascending by pages", user.ListQuery{Limit: 1}, 4},
+		{"created descending by pages", user.ListQuery{Descending: true, Limit: 3}, 4},
+		{"email ascending by pages", user.ListQuery{Sort: user.SortByEmail, Limit: 2}, 4},
+		{"email descending by pages", user.ListQuery{Sort: user.SortByEmail, Descending: true, Limit: 2}, 4},
+		{"ties broken by ID", user.ListQuery{CreatedAfter: users[2].CreatedAt, Limit: 1}, 2},
+		{"email prefix ignores case", user.ListQuery{EmailPrefix: "BO"}, 1},
+		{"role", user.ListQuery{Role: user.RoleStaff}, 1},
+		{"status by pages", user.ListQuery{Status: user.StatusActive, Limit: 1}, 3},
+		{"deleted only when asked for", user.ListQuery{Status: user.StatusDeleted}, 1},
+		{"created range", user.ListQuery{CreatedAfter: users[1].CreatedAt, CreatedBefore: users[2].CreatedAt}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listAll(t, tt.query, func(q user.ListQuery) (*user.ListPage, error) {
+				return repo.List(ctx, q)
+			})
+			want := listAll(t, tt.query, func(q user.ListQuery) (*user.ListPage, error) {
+				return user.Paginate(users, q)
+			})
+			if len(want) != tt.count {
+				t.Fatalf("Paginate() listed %v, want %d users", want, tt.count)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("listed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// listAll follows the cursors of list and returns the IDs in listing order
+func listAll(t *testing.T, q user.ListQuery, list func(user.ListQuery) (*user.ListPage, error)) []string {
+	t.Helper()
+
+	var ids []string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("listing does not end")
+		}
+		page, err := list(q)
+		if err != nil {
+			t.Fatalf("List() = %v", err)
+		}
+		if len(page.Users) > q.Limit && q.Limit > 0 {
+			t.Fatalf("page holds %d users, limit is %d", len(page.Users), q.Limit)
+		}
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == "" {
+			return ids
+		}
+		q.Cursor = page.NextCursor
+	}
+}
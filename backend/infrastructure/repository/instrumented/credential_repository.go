@@ -0,0 +1,59 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// CredentialRepository traces auth.CredentialRepository operations and records their latency
+type CredentialRepository struct {
+	next auth.CredentialRepository
+	observer
+}
+
+// NewCredentialRepository wraps next
+func NewCredentialRepository(next auth.CredentialRepository, m *metrics.Metrics, backend string) *CredentialRepository {
+	return &CredentialRepository{next: next, observer: newObserver(m, "credentials", backend)}
+}
+
+// FindByUserID retrieves the credential of a user
+func (r *CredentialRepository) FindByUserID(ctx context.Context, userID string) (result *auth.Credential, err error) {
+	ctx, done := r.start(ctx, "find_by_user_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByUserID(ctx, userID)
+}
+
+// FindByEmail retrieves a credential by login email
+func (r *CredentialRepository) FindByEmail(ctx context.Context, email string) (result *auth.Credential, err error) {
+	ctx, done := r.start(ctx, "find_by_email")
+	defer func() { done(err) }()
+
+	return r.next.FindByEmail(ctx, email)
+}
+
+// Create creates a new credential
+func (r *CredentialRepository) Create(ctx context.Context, credential *auth.Credential) (err error) {
+	ctx, done := r.start(ctx, "create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, credential)
+}
+
+// Update updates an existing credential
+func (r *CredentialRepository) Update(ctx context.Context, credential *auth.Credential) (err error) {
+	ctx, done := r.start(ctx, "update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, credential)
+}
+
+// Delete removes the credential of a user
+func (r *CredentialRepository) Delete(ctx context.Context, userID string) (err error) {
+	ctx, done := r.start(ctx, "delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, userID)
+}
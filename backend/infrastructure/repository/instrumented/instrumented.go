@@ -0,0 +1,46 @@
+// Package instrumented decorates the domain repositories to record the
+// latency of every operation and trace it, whichever storage backend is in use.
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+	"github.com/yourusername/toolrentalclub/pkg/tracing"
+)
+
+const tracerName = "github.com/yourusername/toolrentalclub/infrastructure/repository/instrumented"
+
+// observer records operations of one repository
+type observer struct {
+	metrics    *metrics.Metrics
+	repository string
+	backend    string
+}
+
+func newObserver(m *metrics.Metrics, repository, backend string) observer {
+	return observer{metrics: m, repository: repository, backend: backend}
+}
+
+// start begins an operation, returning the context to run it in and a
+// function to call with its result once it is done
+func (o observer) start(ctx context.Context, operation string) (context.Context, func(error)) {
+	begin := time.Now()
+	ctx, span := otel.Tracer(tracerName).Start(ctx, o.repository+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("repository", o.repository),
+			attribute.String("repository.backend", o.backend),
+		),
+	)
+
+	return ctx, func(err error) {
+		o.metrics.ObserveRepositoryOperation(o.repository, operation, time.Since(begin))
+		tracing.End(span, err)
+	}
+}
@@ -0,0 +1,74 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// RentalRepository traces rental.Repository operations, records their latency and
+// counts created reservations
+type RentalRepository struct {
+	next rental.Repository
+	observer
+}
+
+// NewRentalRepository wraps next
+func NewRentalRepository(next rental.Repository, m *metrics.Metrics, backend string) *RentalRepository {
+	return &RentalRepository{next: next, observer: newObserver(m, "rentals", backend)}
+}
+
+// FindByID retrieves a rental by its ID
+func (r *RentalRepository) FindByID(ctx context.Context, id string) (result *rental.Rental, err error) {
+	ctx, done := r.start(ctx, "find_by_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByID(ctx, id)
+}
+
+// FindByToolID retrieves every rental of a tool
+func (r *RentalRepository) FindByToolID(ctx context.Context, toolID string) (result []*rental.Rental, err error) {
+	ctx, done := r.start(ctx, "find_by_tool_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByToolID(ctx, toolID)
+}
+
+// FindByRenterID retrieves every rental made by a user
+func (r *RentalRepository) FindByRenterID(ctx context.Context, renterID string) (result []*rental.Rental, err error) {
+	ctx, done := r.start(ctx, "find_by_renter_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByRenterID(ctx, renterID)
+}
+
+// FindOverdue retrieves every rental that is overdue at now
+func (r *RentalRepository) FindOverdue(ctx context.Context, now time.Time) (result []*rental.Rental, err error) {
+	ctx, done := r.start(ctx, "find_overdue")
+	defer func() { done(err) }()
+
+	return r.next.FindOverdue(ctx, now)
+}
+
+// Create creates a new rental
+func (r *RentalRepository) Create(ctx context.Context, rent *rental.Rental) (err error) {
+	ctx, done := r.start(ctx, "create")
+	defer func() { done(err) }()
+
+	if err = r.next.Create(ctx, rent); err != nil {
+		return err
+	}
+
+	r.metrics.ReservationCreated()
+	return nil
+}
+
+// Update updates an existing rental
+func (r *RentalRepository) Update(ctx context.Context, rent *rental.Rental, expected rental.Status) (err error) {
+	ctx, done := r.start(ctx, "update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, rent, expected)
+}
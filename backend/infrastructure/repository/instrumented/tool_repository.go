@@ -0,0 +1,94 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// ToolRepository traces tool.Repository operations and records their latency
+type ToolRepository struct {
+	next tool.Repository
+	observer
+}
+
+// NewToolRepository wraps next
+func NewToolRepository(next tool.Repository, m *metrics.Metrics, backend string) *ToolRepository {
+	return &ToolRepository{next: next, observer: newObserver(m, "tools", backend)}
+}
+
+// FindByID retrieves a tool by its ID
+func (r *ToolRepository) FindByID(ctx context.Context, id string) (result *tool.Tool, err error) {
+	ctx, done := r.start(ctx, "find_by_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByID(ctx, id)
+}
+
+// FindAll retrieves every tool in the catalog
+func (r *ToolRepository) FindAll(ctx context.Context) (result []*tool.Tool, err error) {
+	ctx, done := r.start(ctx, "find_all")
+	defer func() { done(err) }()
+
+	return r.next.FindAll(ctx)
+}
+
+// Create creates a new tool
+func (r *ToolRepository) Create(ctx context.Context, t *tool.Tool) (err error) {
+	ctx, done := r.start(ctx, "create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, t)
+}
+
+// Update updates an existing tool
+func (r *ToolRepository) Update(ctx context.Context, t *tool.Tool) (err error) {
+	ctx, done := r.start(ctx, "update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, t)
+}
+
+// Delete removes a tool by its ID
+func (r *ToolRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := r.start(ctx, "delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, id)
+}
+
+// ToolBlockRepository traces tool.BlockRepository operations and records their latency
+type ToolBlockRepository struct {
+	next tool.BlockRepository
+	observer
+}
+
+// NewToolBlockRepository wraps next
+func NewToolBlockRepository(next tool.BlockRepository, m *metrics.Metrics, backend string) *ToolBlockRepository {
+	return &ToolBlockRepository{next: next, observer: newObserver(m, "tool_blocks", backend)}
+}
+
+// FindByToolID retrieves every block of a tool
+func (r *ToolBlockRepository) FindByToolID(ctx context.Context, toolID string) (result []*tool.Block, err error) {
+	ctx, done := r.start(ctx, "find_by_tool_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByToolID(ctx, toolID)
+}
+
+// Create creates a new block
+func (r *ToolBlockRepository) Create(ctx context.Context, b *tool.Block) (err error) {
+	ctx, done := r.start(ctx, "create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, b)
+}
+
+// Delete removes a block by its ID
+func (r *ToolBlockRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, done := r.start(ctx, "delete")
+	defer func() { done(err) }()
+
+	return r.next.Delete(ctx, id)
+}
@@ -0,0 +1,59 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// UserRepository traces user.Repository operations and records their latency
+type UserRepository struct {
+	next user.Repository
+	observer
+}
+
+// NewUserRepository wraps next
+func NewUserRepository(next user.Repository, m *metrics.Metrics, backend string) *UserRepository {
+	return &UserRepository{next: next, observer: newObserver(m, "users", backend)}
+}
+
+// FindByID retrieves a user by their ID
+func (r *UserRepository) FindByID(ctx context.Context, id string) (result *user.User, err error) {
+	ctx, done := r.start(ctx, "find_by_id")
+	defer func() { done(err) }()
+
+	return r.next.FindByID(ctx, id)
+}
+
+// FindByEmail retrieves a user by their email
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (result *user.User, err error) {
+	ctx, done := r.start(ctx, "find_by_email")
+	defer func() { done(err) }()
+
+	return r.next.FindByEmail(ctx, email)
+}
+
+// Create creates a new user
+func (r *UserRepository) Create(ctx context.Context, u *user.User) (err error) {
+	ctx, done := r.start(ctx, "create")
+	defer func() { done(err) }()
+
+	return r.next.Create(ctx, u)
+}
+
+// Update updates an existing user
+func (r *UserRepository) Update(ctx context.Context, u *user.User) (err error) {
+	ctx, done := r.start(ctx, "update")
+	defer func() { done(err) }()
+
+	return r.next.Update(ctx, u)
+}
+
+// List retrieves a page of the users matching the query
+func (r *UserRepository) List(ctx context.Context, query user.ListQuery) (result *user.ListPage, err error) {
+	ctx, done := r.start(ctx, "list")
+	defer func() { done(err) }()
+
+	return r.next.List(ctx, query)
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+// CredentialRepository implements auth.CredentialRepository interface using in-memory storage
+type CredentialRepository struct {
+	mu          sync.RWMutex
+	credentials map[string]auth.Credential // key is user ID
+	index       map[string]string          // email -> user ID index
+}
+
+// NewCredentialRepository creates a new in-memory credential repository
+func NewCredentialRepository() *CredentialRepository {
+	return &CredentialRepository{
+		credentials: make(map[string]auth.Credential),
+		index:       make(map[string]string),
+	}
+}
+
+// FindByUserID retrieves the credential of a user
+func (r *CredentialRepository) FindByUserID(ctx context.Context, userID string) (*auth.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credential, exists := r.credentials[userID]
+	if !exists {
+		return nil, auth.ErrCredentialNotFound
+	}
+
+	return &credential, nil
+}
+
+// FindByEmail retrieves a credential by login email
+func (r *CredentialRepository) FindByEmail(ctx context.Context, email string) (*auth.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userID, exists := r.index[email]
+	if !exists {
+		return nil, auth.ErrCredentialNotFound
+	}
+
+	credential := r.credentials[userID]
+	return &credential, nil
+}
+
+// Create creates a new credential
+func (r *CredentialRepository) Create(ctx context.Context, credential *auth.Credential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.index[credential.Email]; exists {
+		return auth.ErrEmailTaken
+	}
+	if _, exists := r.credentials[credential.UserID]; exists {
+		return auth.ErrEmailTaken
+	}
+
+	r.credentials[credential.UserID] = *credential
+	r.index[credential.Email] = credential.UserID
+
+	return nil
+}
+
+// Update updates an existing credential
+func (r *CredentialRepository) Update(ctx context.Context, credential *auth.Credential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.credentials[credential.UserID]
+	if !exists {
+		return auth.ErrCredentialNotFound
+	}
+
+	if existing.Email != credential.Email {
+		if _, taken := r.index[credential.Email]; taken {
+			return auth.ErrEmailTaken
+		}
+		delete(r.index, existing.Email)
+		r.index[credential.Email] = credential.UserID
+	}
+
+	r.credentials[credential.UserID] = *credential
+
+	return nil
+}
+
+// Delete removes the credential of a user
+func (r *CredentialRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	credential, exists := r.credentials[userID]
+	if !exists {
+		return auth.ErrCredentialNotFound
+	}
+
+	delete(r.index, credential.Email)
+	delete(r.credentials, userID)
+
+	return nil
+}
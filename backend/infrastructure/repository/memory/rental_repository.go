@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+)
+
+// RentalRepository implements rental.Repository interface using in-memory storage.
+// A single lock guards both the conflict check and the write, which makes
+// Create and Update atomic with respect to concurrent bookings. Writes also
+// hold the read lock of the block repository, so no block is added between
+// the block check and the write.
+type RentalRepository struct {
+	mu      sync.RWMutex
+	rentals map[string]rental.Rental // key is rental ID
+	byTool  map[string][]string      // tool ID -> rental IDs index
+	blocks  *ToolBlockRepository
+}
+
+// NewRentalRepository creates a new in-memory rental repository that checks
+// reservations against the blocks stored in blocks
+func NewRentalRepository(blocks *ToolBlockRepository) *RentalRepository {
+	return &RentalRepository{
+		rentals: make(map[string]rental.Rental),
+		byTool:  make(map[string][]string),
+		blocks:  blocks,
+	}
+}
+
+// FindByID retrieves a rental by its ID
+func (r *RentalRepository) FindByID(ctx context.Context, id string) (*rental.Rental, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rent, exists := r.rentals[id]
+	if !exists {
+		return nil, rental.ErrRentalNotFound
+	}
+
+	return &rent, nil
+}
+
+// FindByToolID retrieves every rental of a tool, ordered by start date
+func (r *RentalRepository) FindByToolID(ctx context.Context, toolID string) ([]*rental.Rental, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.toolRentals(toolID), nil
+}
+
+// FindByRenterID retrieves every rental made by a user, ordered by start date
+func (r *RentalRepository) FindByRenterID(ctx context.Context, renterID string) ([]*rental.Rental, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rentals := make([]*rental.Rental, 0)
+	for _, rent := range r.rentals {
+		if rent.RenterID == renterID {
+			rent := rent
+			rentals = append(rentals, &rent)
+		}
+	}
+
+	sortByStart(rentals)
+	return rentals, nil
+}
+
+// FindOverdue retrieves every rental that is overdue at now, ordered by end date
+func (r *RentalRepository) FindOverdue(ctx context.Context, now time.Time) ([]*rental.Rental, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	overdue := make([]*rental.Rental, 0)
+	for _, rent := range r.rentals {
+		if rent.IsOverdue(now) {
+			rent := rent
+			overdue = append(overdue, &rent)
+		}
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		if overdue[i].End.Equal(overdue[j].End) {
+			return overdue[i].ID < overdue[j].ID
+		}
+		return overdue[i].End.Before(overdue[j].End)
+	})
+
+	return overdue, nil
+}
+
+// Create creates a new rental
+func (r *RentalRepository) Create(ctx context.Context, rent *rental.Rental) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rentals[rent.ID]; exists {
+		return rental.ErrRentalExists
+	}
+
+	if err := rent.CheckConflicts(r.toolRentals(rent.ToolID), true); err != nil {
+		return err
+	}
+
+	r.blocks.mu.RLock()
+	defer r.blocks.mu.RUnlock()
+
+	if err := rent.CheckBlocks(r.blocks.toolBlocks(rent.ToolID), ""); err != nil {
+		return err
+	}
+
+	r.rentals[rent.ID] = *rent
+	r.byTool[rent.ToolID] = append(r.byTool[rent.ToolID], rent.ID)
+
+	return nil
+}
+
+// Update updates an existing rental
+func (r *RentalRepository) Update(ctx context.Context, rent *rental.Rental, expected rental.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.rentals[rent.ID]
+	if !exists {
+		return rental.ErrRentalNotFound
+	}
+	if existing.Status != expected {
+		return fmt.Errorf("%w: rental was changed concurrently", rental.ErrInvalidTransition)
+	}
+
+	if err := rent.CheckConflicts(r.toolRentals(rent.ToolID), false); err != nil {
+		return err
+	}
+
+	r.blocks.mu.RLock()
+	defer r.blocks.mu.RUnlock()
+
+	if err := rent.CheckBlocks(r.blocks.toolBlocks(rent.ToolID), existing.Status); err != nil {
+		return err
+	}
+
+	// If the tool changed, move the rental in the index
+	if existing.ToolID != rent.ToolID {
+		r.byTool[existing.ToolID] = removeID(r.byTool[existing.ToolID], rent.ID)
+		r.byTool[rent.ToolID] = append(r.byTool[rent.ToolID], rent.ID)
+	}
+
+	r.rentals[rent.ID] = *rent
+
+	return nil
+}
+
+// toolRentals returns copies of a tool's rentals ordered by start date.
+// The caller must hold the lock.
+func (r *RentalRepository) toolRentals(toolID string) []*rental.Rental {
+	ids := r.byTool[toolID]
+	rentals := make([]*rental.Rental, 0, len(ids))
+	for _, id := range ids {
+		rent := r.rentals[id]
+		rentals = append(rentals, &rent)
+	}
+
+	sortByStart(rentals)
+	return rentals
+}
+
+// sortByStart orders rentals by start date, then ID
+func sortByStart(rentals []*rental.Rental) {
+	sort.Slice(rentals, func(i, j int) bool {
+		if rentals[i].Start.Equal(rentals[j].Start) {
+			return rentals[i].ID < rentals[j].ID
+		}
+		return rentals[i].Start.Before(rentals[j].Start)
+	})
+}
+
+func removeID(ids []string, id string) []string {
+	for i, existing := range ids {
+		if existing == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
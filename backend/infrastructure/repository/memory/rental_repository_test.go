@@ -0,0 +1,259 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// day returns midnight UTC of the given day in January 2030
+func day(d int) time.Time {
+	return time.Date(2030, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+// newRental returns a rental of the drill in the given status
+func newRental(id string, status rental.Status, start, end time.Time) *rental.Rental {
+	r := rental.NewRental(id, "drill", "renter", start, end)
+	r.Status = status
+	return r
+}
+
+func TestRentalRepositoryCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *rental.Rental
+		rental   *rental.Rental
+		want     error
+	}{
+		{"free dates", newRental("r1", rental.StatusConfirmed, day(1), day(5)), newRental("r2", rental.StatusRequested, day(5), day(8)), nil},
+		{"overlapping confirmed", newRental("r1", rental.StatusConfirmed, day(1), day(5)), newRental("r2", rental.StatusRequested, day(4), day(8)), rental.ErrConflict},
+		{"overlapping requested", newRental("r1", rental.StatusRequested, day(1), day(5)), newRental("r2", rental.StatusRequested, day(4), day(8)), nil},
+		{"taken ID", newRental("r1", rental.StatusRequested, day(1), day(5)), newRental("r1", rental.StatusRequested, day(10), day(12)), rental.ErrRentalExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewRentalRepository(NewToolBlockRepository())
+			if err := repo.Create(ctx, tt.existing); err != nil {
+				t.Fatalf("Create(existing) = %v", err)
+			}
+
+			if err := repo.Create(ctx, tt.rental); !errors.Is(err, tt.want) {
+				t.Errorf("Create() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalRepositoryUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     rental.Status
+		expected rental.Status
+		other    *rental.Rental // another rental of the drill, if any
+		want     error
+	}{
+		{"expected status", rental.StatusConfirmed, rental.StatusRequested, nil, nil},
+		{"changed concurrently", rental.StatusConfirmed, rental.StatusConfirmed, nil, rental.ErrInvalidTransition},
+		{"confirming next to confirmed", rental.StatusConfirmed, rental.StatusRequested, newRental("r2", rental.StatusConfirmed, day(5), day(9)), nil},
+		{"confirming over confirmed", rental.StatusConfirmed, rental.StatusRequested, newRental("r2", rental.StatusConfirmed, day(4), day(9)), rental.ErrConflict},
+		{"cancelling over confirmed", rental.StatusCancelled, rental.StatusRequested, newRental("r2", rental.StatusConfirmed, day(4), day(9)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewRentalRepository(NewToolBlockRepository())
+			if err := repo.Create(ctx, newRental("r1", rental.StatusRequested, day(1), day(5))); err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if tt.other != nil {
+				// Store the other rental as requested, then confirm it
+				status := tt.other.Status
+				tt.other.Status = rental.StatusRequested
+				if err := repo.Create(ctx, tt.other); err != nil {
+					t.Fatalf("Create(other) = %v", err)
+				}
+				tt.other.Status = status
+				if err := repo.Update(ctx, tt.other, rental.StatusRequested); err != nil {
+					t.Fatalf("Update(other) = %v", err)
+				}
+			}
+
+			rent, err := repo.FindByID(ctx, "r1")
+			if err != nil {
+				t.Fatalf("FindByID() = %v", err)
+			}
+			rent.Status = tt.next
+
+			err = repo.Update(ctx, rent, tt.expected)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Update() = %v, want %v", err, tt.want)
+			}
+
+			stored, _ := repo.FindByID(ctx, "r1")
+			want := tt.next
+			if err != nil {
+				want = rental.StatusRequested
+			}
+			if stored.Status != want {
+				t.Errorf("stored status = %s, want %s", stored.Status, want)
+			}
+		})
+	}
+}
+
+func TestRentalRepositoryBlocks(t *testing.T) {
+	ctx := context.Background()
+	blocks := NewToolBlockRepository()
+	repo := NewRentalRepository(blocks)
+
+	// Requested and confirmed before the drill went into maintenance
+	for _, r := range []*rental.Rental{
+		newRental("requested", rental.StatusRequested, day(1), day(5)),
+		newRental("confirmed", rental.StatusRequested, day(5), day(9)),
+	} {
+		if err := repo.Create(ctx, r); err != nil {
+			t.Fatalf("Create(%s) = %v", r.ID, err)
+		}
+	}
+	confirmed, _ := repo.FindByID(ctx, "confirmed")
+	confirmed.Status = rental.StatusConfirmed
+	if err := repo.Update(ctx, confirmed, rental.StatusRequested); err != nil {
+		t.Fatalf("Update(confirmed) = %v", err)
+	}
+
+	if err := blocks.Create(ctx, tool.NewBlock("b1", "drill", tool.BlockMaintenance, day(3), day(6), "")); err != nil {
+		t.Fatalf("Create(block) = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		id   string
+		next rental.Status
+		want error
+	}{
+		{"confirming over the block", "requested", rental.StatusConfirmed, rental.ErrToolBlocked},
+		{"cancelling over the block", "requested", rental.StatusCancelled, nil},
+		{"picking up over the later block", "confirmed", rental.StatusPickedUp, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rent, err := repo.FindByID(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("FindByID() = %v", err)
+			}
+			prev := rent.Status
+			rent.Status = tt.next
+
+			if err := repo.Update(ctx, rent, prev); !errors.Is(err, tt.want) {
+				t.Errorf("Update() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("requesting over the block", func(t *testing.T) {
+		err := repo.Create(ctx, newRental("new", rental.StatusRequested, day(2), day(4)))
+		if !errors.Is(err, rental.ErrToolBlocked) {
+			t.Errorf("Create() = %v, want ErrToolBlocked", err)
+		}
+	})
+}
+
+func TestRentalRepositoryUpdateMissing(t *testing.T) {
+	repo := NewRentalRepository(NewToolBlockRepository())
+	err := repo.Update(context.Background(), newRental("r1", rental.StatusConfirmed, day(1), day(5)), rental.StatusRequested)
+	if !errors.Is(err, rental.ErrRentalNotFound) {
+		t.Errorf("Update() = %v, want ErrRentalNotFound", err)
+	}
+}
+
+// TestRentalRepositoryConcurrentConfirmations confirms overlapping requests
+// at the same time; exactly one of them may hold the tool
+func TestRentalRepositoryConcurrentConfirmations(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRentalRepository(NewToolBlockRepository())
+
+	const requests = 20
+	for i := 0; i < requests; i++ {
+		if err := repo.Create(ctx, newRental(fmt.Sprint("r", i), rental.StatusRequested, day(1+i%3), day(5))); err != nil {
+			t.Fatalf("Create() = %v", err)
+		}
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		confirmed int
+	)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			rent, err := repo.FindByID(ctx, id)
+			if err != nil {
+				t.Errorf("FindByID() = %v", err)
+				return
+			}
+			if err := rent.TransitionTo(rental.StatusConfirmed); err != nil {
+				t.Errorf("TransitionTo() = %v", err)
+				return
+			}
+
+			err = repo.Update(ctx, rent, rental.StatusRequested)
+			switch {
+			case err == nil:
+				mu.Lock()
+				confirmed++
+				mu.Unlock()
+			case !errors.Is(err, rental.ErrConflict):
+				t.Errorf("Update() = %v, want nil or ErrConflict", err)
+			}
+		}(fmt.Sprint("r", i))
+	}
+	wg.Wait()
+
+	if confirmed != 1 {
+		t.Errorf("%d overlapping rentals confirmed, want 1", confirmed)
+	}
+}
+
+func TestRentalRepositoryFindOverdue(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRentalRepository(NewToolBlockRepository())
+
+	rentals := []*rental.Rental{
+		newRental("late", rental.StatusPickedUp, day(1), day(3)),
+		newRental("later", rental.StatusPickedUp, day(3), day(5)),
+		newRental("on time", rental.StatusPickedUp, day(5), day(12)),
+		newRental("returned", rental.StatusReturned, day(12), day(13)),
+	}
+	for _, rent := range rentals {
+		status := rent.Status
+		rent.Status = rental.StatusRequested
+		if err := repo.Create(ctx, rent); err != nil {
+			t.Fatalf("Create() = %v", err)
+		}
+		rent.Status = status
+		if err := repo.Update(ctx, rent, rental.StatusRequested); err != nil {
+			t.Fatalf("Update() = %v", err)
+		}
+	}
+
+	overdue, err := repo.FindOverdue(ctx, day(10))
+	if err != nil {
+		t.Fatalf("FindOverdue() = %v", err)
+	}
+	var ids []string
+	for _, rent := range overdue {
+		ids = append(ids, rent.ID)
+	}
+	if fmt.Sprint(ids) != "[late later]" {
+		t.Errorf("overdue = %v, want [late later]", ids)
+	}
+}
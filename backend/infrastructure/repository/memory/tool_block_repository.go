@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolBlockRepository implements tool.BlockRepository interface using in-memory
+// storage. Its lock is also held by RentalRepository writes (see there).
+type ToolBlockRepository struct {
+	mu     sync.RWMutex
+	blocks map[string]tool.Block // key is block ID
+}
+
+// NewToolBlockRepository creates a new in-memory tool block repository
+func NewToolBlockRepository() *ToolBlockRepository {
+	return &ToolBlockRepository{
+		blocks: make(map[string]tool.Block),
+	}
+}
+
+// FindByToolID retrieves every block of a tool, ordered by start date
+func (r *ToolBlockRepository) FindByToolID(ctx context.Context, toolID string) ([]*tool.Block, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.toolBlocks(toolID), nil
+}
+
+// toolBlocks returns copies of a tool's blocks ordered by start date.
+// The caller must hold the lock.
+func (r *ToolBlockRepository) toolBlocks(toolID string) []*tool.Block {
+	blocks := make([]*tool.Block, 0)
+	for _, b := range r.blocks {
+		if b.ToolID == toolID {
+			b := b
+			blocks = append(blocks, &b)
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].Start.Equal(blocks[j].Start) {
+			return blocks[i].ID < blocks[j].ID
+		}
+		return blocks[i].Start.Before(blocks[j].Start)
+	})
+
+	return blocks
+}
+
+// Create creates a new block
+func (r *ToolBlockRepository) Create(ctx context.Context, b *tool.Block) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.blocks[b.ID] = *b
+
+	return nil
+}
+
+// Delete removes a block by its ID
+func (r *ToolBlockRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.blocks[id]; !exists {
+		return tool.ErrBlockNotFound
+	}
+
+	delete(r.blocks, id)
+
+	return nil
+}
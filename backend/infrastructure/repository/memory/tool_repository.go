@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolRepository implements tool.Repository interface using in-memory storage
+type ToolRepository struct {
+	mu    sync.RWMutex
+	tools map[string]tool.Tool // key is tool ID
+}
+
+// NewToolRepository creates a new in-memory tool repository
+func NewToolRepository() *ToolRepository {
+	return &ToolRepository{
+		tools: make(map[string]tool.Tool),
+	}
+}
+
+// FindByID retrieves a tool by its ID
+func (r *ToolRepository) FindByID(ctx context.Context, id string) (*tool.Tool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, exists := r.tools[id]
+	if !exists {
+		return nil, tool.ErrToolNotFound
+	}
+
+	return &t, nil
+}
+
+// FindAll retrieves every tool, oldest first
+func (r *ToolRepository) FindAll(ctx context.Context) ([]*tool.Tool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tools := make([]*tool.Tool, 0, len(r.tools))
+	for _, t := range r.tools {
+		t := t
+		tools = append(tools, &t)
+	}
+
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].CreatedAt.Equal(tools[j].CreatedAt) {
+			return tools[i].ID < tools[j].ID
+		}
+		return tools[i].CreatedAt.Before(tools[j].CreatedAt)
+	})
+
+	return tools, nil
+}
+
+// Create creates a new tool
+func (r *ToolRepository) Create(ctx context.Context, t *tool.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[t.ID]; exists {
+		return tool.ErrToolExists
+	}
+
+	r.tools[t.ID] = *t
+
+	return nil
+}
+
+// Update updates an existing tool
+func (r *ToolRepository) Update(ctx context.Context, t *tool.Tool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[t.ID]; !exists {
+		return tool.ErrToolNotFound
+	}
+
+	r.tools[t.ID] = *t
+
+	return nil
+}
+
+// Delete removes a tool by its ID
+func (r *ToolRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tools[id]; !exists {
+		return tool.ErrToolNotFound
+	}
+
+	delete(r.tools, id)
+
+	return nil
+}
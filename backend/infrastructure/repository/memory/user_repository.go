@@ -2,23 +2,25 @@ package memory
 
 import (
 	"context"
-	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/yourusername/toolrentalclub/domain/user"
 )
 
-// UserRepository implements user.Repository interface using in-memory storage
+// UserRepository implements user.Repository interface using in-memory storage.
+// Users are stored by value so that callers changing a user they loaded do
+// not change the stored copy before calling Update.
 type UserRepository struct {
 	mu    sync.RWMutex
-	users map[string]*user.User // key is user ID
-	index map[string]string     // email -> user ID index
+	users map[string]user.User // key is user ID
+	index map[string]string    // email -> user ID index; users without an email are not indexed
 }
 
 // NewUserRepository creates a new in-memory user repository
 func NewUserRepository() *UserRepository {
 	return &UserRepository{
-		users: make(map[string]*user.User),
+		users: make(map[string]user.User),
 		index: make(map[string]string),
 	}
 }
@@ -28,12 +30,12 @@ func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, e
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	user, exists := r.users[id]
+	u, exists := r.users[id]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, user.ErrUserNotFound
 	}
 
-	return user, nil
+	return cloneUser(u), nil
 }
 
 // FindByEmail retrieves a user by their email
@@ -42,53 +44,79 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.U
 	defer r.mu.RUnlock()
 
 	userID, exists := r.index[email]
-	if !exists {
-		return nil, fmt.Errorf("user not found")
+	if !exists || email == "" {
+		return nil, user.ErrUserNotFound
 	}
 
-	return r.users[userID], nil
+	return cloneUser(r.users[userID]), nil
 }
 
 // Create creates a new user
-func (r *UserRepository) Create(ctx context.Context, user *user.User) error {
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Check if user already exists
-	if _, exists := r.users[user.ID]; exists {
-		return fmt.Errorf("user already exists")
+	if _, exists := r.users[u.ID]; exists {
+		return user.ErrUserExists
 	}
 
 	// Check if email is already taken
-	if _, exists := r.index[user.Email]; exists {
-		return fmt.Errorf("email already taken")
+	if _, exists := r.index[u.Email]; exists && u.Email != "" {
+		return user.ErrEmailTaken
 	}
 
-	r.users[user.ID] = user
-	r.index[user.Email] = user.ID
+	r.users[u.ID] = *cloneUser(*u)
+	if u.Email != "" {
+		r.index[u.Email] = u.ID
+	}
 
 	return nil
 }
 
-// Update updates an existing user
-func (r *UserRepository) Update(ctx context.Context, user *user.User) error {
+// Update updates an existing user. Changing the email moves its index entry,
+// unless another user already has the new email.
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	// Check if user exists
-	existingUser, exists := r.users[user.ID]
+	existingUser, exists := r.users[u.ID]
 	if !exists {
-		return fmt.Errorf("user not found")
+		return user.ErrUserNotFound
 	}
 
 	// If email changed, update index
-	if existingUser.Email != user.Email {
+	if existingUser.Email != u.Email {
+		if owner, taken := r.index[u.Email]; taken && owner != u.ID && u.Email != "" {
+			return user.ErrEmailTaken
+		}
 		delete(r.index, existingUser.Email)
-		r.index[user.Email] = user.ID
+		if u.Email != "" {
+			r.index[u.Email] = u.ID
+		}
 	}
 
-	r.users[user.ID] = user
+	r.users[u.ID] = *cloneUser(*u)
 
 	return nil
 }
 
+// List retrieves a page of the users matching the query
+func (r *UserRepository) List(ctx context.Context, query user.ListQuery) (*user.ListPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*user.User, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, cloneUser(u))
+	}
+
+	return user.Paginate(users, query)
+}
+
+// cloneUser copies u, including its roles, so the copy shares nothing with it
+func cloneUser(u user.User) *user.User {
+	u.Roles = slices.Clone(u.Roles)
+	return &u
+}
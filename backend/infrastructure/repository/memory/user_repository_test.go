@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+func TestUserRepositoryEmailIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, repo *UserRepository) error
+		want   error
+		emails map[string]string // email -> ID of the user it should find; "" for none
+	}{
+		{
+			name: "create with taken email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u3", "dora@example.com"))
+			},
+			want:   user.ErrEmailTaken,
+			emails: map[string]string{"dora@example.com": "u1"},
+		},
+		{
+			name: "create with taken ID",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u1", "new@example.com"))
+			},
+			want:   user.ErrUserExists,
+			emails: map[string]string{"new@example.com": ""},
+		},
+		{
+			name: "users without email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				if err := repo.Create(ctx, user.NewUser("u3", "")); err != nil {
+					return err
+				}
+				return repo.Create(ctx, user.NewUser("u4", ""))
+			},
+			emails: map[string]string{"": ""},
+		},
+		{
+			name: "email changed",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, _ := repo.FindByID(ctx, "u1")
+				u.Email = "dora@new.example.com"
+				return repo.Update(ctx, u)
+			},
+			emails: map[string]string{"dora@example.com": "", "dora@new.example.com": "u1"},
+		},
+		{
+			name: "email changed to a taken one",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, _ := repo.FindByID(ctx, "u1")
+				u.Email = "bob@example.com"
+				return repo.Update(ctx, u)
+			},
+			want:   user.ErrEmailTaken,
+			emails: map[string]string{"dora@example.com": "u1", "bob@example.com": "u2"},
+		},
+		{
+			name: "email cleared",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, _ := repo.FindByID(ctx, "u1")
+				u.Erase()
+				return repo.Update(ctx, u)
+			},
+			emails: map[string]string{"dora@example.com": "", "": ""},
+		},
+		{
+			name: "missing user",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Update(ctx, user.NewUser("u3", "carol@example.com"))
+			},
+			want:   user.ErrUserNotFound,
+			emails: map[string]string{"carol@example.com": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewUserRepository()
+			for _, u := range []*user.User{user.NewUser("u1", "dora@example.com"), user.NewUser("u2", "bob@example.com")} {
+				if err := repo.Create(ctx, u); err != nil {
+					t.Fatalf("Create() = %v", err)
+				}
+			}
+
+			if err := tt.change(ctx, repo); !errors.Is(err, tt.want) {
+				t.Fatalf("change returned %v, want %v", err, tt.want)
+			}
+
+			for email, id := range tt.emails {
+				u, err := repo.FindByEmail(ctx, email)
+				switch {
+				case id == "" && !errors.Is(err, user.ErrUserNotFound):
+					t.Errorf("FindByEmail(%q) = %v, %v, want ErrUserNotFound", email, u, err)
+				case id != "" && (err != nil || u.ID != id):
+					t.Errorf("FindByEmail(%q) = %v, %v, want user %s", email, u, err, id)
+				}
+			}
+		})
+	}
+}
+
+func TestUserRepositoryReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository()
+
+	u := user.NewUser("u1", "dora@example.com")
+	u.Roles = []user.Role{user.RoleToolOwner}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("Create() = %v", err)
+	}
+	u.Roles[0] = user.RoleAdmin
+
+	loaded, _ := repo.FindByID(ctx, "u1")
+	loaded.Roles[0] = user.RoleAdmin
+	loaded.Profile.DisplayName = "changed without Update"
+
+	stored, _ := repo.FindByID(ctx, "u1")
+	if stored.Roles[0] != user.RoleToolOwner || stored.Profile.DisplayName != "" {
+		t.Errorf("stored user changed outside Update: %+v", stored)
+	}
+}
+
+func TestUserRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository()
+
+	for _, id := range []string{"u1", "u2", "u3"} {
+		if err := repo.Create(ctx, user.NewUser(id, id+"@example.com")); err != nil {
+			t.Fatalf("Create() = %v", err)
+		}
+	}
+	deleted, _ := repo.FindByID(ctx, "u2")
+	deleted.Erase()
+	if err := repo.Update(ctx, deleted); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query user.ListQuery
+		want  int
+	}{
+		{"deleted left out", user.ListQuery{}, 2},
+		{"deleted on request", user.ListQuery{Status: user.StatusDeleted}, 1},
+		{"email prefix", user.ListQuery{EmailPrefix: "U3"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := repo.List(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("List() = %v", err)
+			}
+			if len(page.Users) != tt.want {
+				t.Errorf("listed %d users, want %d", len(page.Users), tt.want)
+			}
+		})
+	}
+}
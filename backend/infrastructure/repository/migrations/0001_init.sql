@@ -0,0 +1,55 @@
+-- Initial schema for users, tools, tool blocks and rentals.
+-- Timestamps are stored in UTC without a time zone so the same
+-- migrations run unchanged on every SQL backend.
+
+CREATE TABLE users (
+    id         TEXT PRIMARY KEY,
+    email      TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Users signing in without an email address (e.g. phone auth) are allowed
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE email <> '';
+
+CREATE TABLE tools (
+    id                      TEXT PRIMARY KEY,
+    name                    TEXT NOT NULL,
+    description             TEXT NOT NULL,
+    category                TEXT NOT NULL,
+    condition               TEXT NOT NULL,
+    replacement_value_cents BIGINT NOT NULL,
+    daily_rate_cents        BIGINT NOT NULL,
+    owner_id                TEXT NOT NULL,
+    status                  TEXT NOT NULL,
+    created_at              TIMESTAMP NOT NULL,
+    updated_at              TIMESTAMP NOT NULL
+);
+
+CREATE INDEX tools_owner_id_idx ON tools (owner_id);
+
+CREATE TABLE tool_blocks (
+    id         TEXT PRIMARY KEY,
+    tool_id    TEXT NOT NULL,
+    kind       TEXT NOT NULL,
+    start_at   TIMESTAMP NOT NULL,
+    end_at     TIMESTAMP NOT NULL,
+    reason     TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX tool_blocks_tool_id_idx ON tool_blocks (tool_id, start_at);
+
+CREATE TABLE rentals (
+    id         TEXT PRIMARY KEY,
+    tool_id    TEXT NOT NULL,
+    renter_id  TEXT NOT NULL,
+    start_at   TIMESTAMP NOT NULL,
+    end_at     TIMESTAMP NOT NULL,
+    status     TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX rentals_tool_id_idx ON rentals (tool_id, start_at);
+CREATE INDEX rentals_renter_id_idx ON rentals (renter_id);
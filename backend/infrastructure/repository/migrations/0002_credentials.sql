@@ -0,0 +1,11 @@
+-- Password credentials for the self-hosted JWT auth provider.
+
+CREATE TABLE credentials (
+    user_id       TEXT PRIMARY KEY,
+    email         TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    created_at    TIMESTAMP NOT NULL,
+    updated_at    TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX credentials_email_key ON credentials (email);
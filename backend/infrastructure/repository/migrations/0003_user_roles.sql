@@ -0,0 +1,4 @@
+-- Roles granted to users on top of the implicit member role, stored as a
+-- comma-separated list such as "tool_owner,staff".
+
+ALTER TABLE users ADD COLUMN roles TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Supports looking up picked-up rentals whose end date has passed.
+
+CREATE INDEX rentals_status_end_idx ON rentals (status, end_at);
@@ -0,0 +1,19 @@
+-- Member profiles: contact details, postal address, avatar, bio and
+-- notification preferences. SQLite only accepts one column per ALTER TABLE.
+
+ALTER TABLE users ADD COLUMN display_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN phone TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_line1 TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_line2 TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_city TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_region TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_postal_code TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN address_country TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN avatar_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN bio TEXT NOT NULL DEFAULT '';
+
+-- Existing members keep the defaults of new members
+ALTER TABLE users ADD COLUMN notify_rental_updates BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN notify_reminders BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE users ADD COLUMN notify_sms BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN notify_newsletter BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+-- Account status: pending approval, active, suspended or closed, with the
+-- reason given to the member and the end of a temporary suspension.
+
+ALTER TABLE users ADD COLUMN status TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMP NULL;
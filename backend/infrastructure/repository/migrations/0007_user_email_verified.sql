@@ -0,0 +1,4 @@
+-- Whether the identity provider has verified the user's email, synced from
+-- the token on sign-in.
+
+ALTER TABLE users ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
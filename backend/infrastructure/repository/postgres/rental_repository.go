@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+)
+
+// RentalRepository implements rental.Repository interface using PostgreSQL.
+// Writes take a transaction-scoped advisory lock on the rental's tool before
+// checking for conflicts and blocks, so concurrent bookings of the same tool
+// are serialized while bookings of different tools proceed in parallel.
+// ToolBlockRepository.Create takes the same lock.
+type RentalRepository struct {
+	db *sql.DB
+}
+
+// NewRentalRepository creates a new PostgreSQL rental repository
+func NewRentalRepository(db *sql.DB) *RentalRepository {
+	return &RentalRepository{db: db}
+}
+
+const rentalColumns = `id, tool_id, renter_id, start_at, end_at, status, created_at, updated_at`
+
+// FindByID retrieves a rental by its ID
+func (r *RentalRepository) FindByID(ctx context.Context, id string) (*rental.Rental, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+rentalColumns+` FROM rentals WHERE id = $1`, id)
+
+	rent, err := scanRental(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, rental.ErrRentalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rental: %w", err)
+	}
+
+	return rent, nil
+}
+
+// FindByToolID retrieves every rental of a tool, ordered by start date
+func (r *RentalRepository) FindByToolID(ctx context.Context, toolID string) ([]*rental.Rental, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+rentalColumns+` FROM rentals WHERE tool_id = $1 ORDER BY start_at, id`,
+		toolID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rentals: %w", err)
+	}
+
+	return scanRentals(rows)
+}
+
+// FindByRenterID retrieves every rental made by a user, ordered by start date
+func (r *RentalRepository) FindByRenterID(ctx context.Context, renterID string) ([]*rental.Rental, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+rentalColumns+` FROM rentals WHERE renter_id = $1 ORDER BY start_at, id`,
+		renterID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rentals: %w", err)
+	}
+
+	return scanRentals(rows)
+}
+
+// FindOverdue retrieves every rental that is overdue at now, ordered by end date
+func (r *RentalRepository) FindOverdue(ctx context.Context, now time.Time) ([]*rental.Rental, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+rentalColumns+` FROM rentals WHERE status = $1 AND end_at <= $2 ORDER BY end_at, id`,
+		rental.StatusPickedUp, now.UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue rentals: %w", err)
+	}
+
+	return scanRentals(rows)
+}
+
+// Create creates a new rental
+func (r *RentalRepository) Create(ctx context.Context, rent *rental.Rental) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := checkConflicts(ctx, tx, rent, true); err != nil {
+			return err
+		}
+		if err := checkBlocks(ctx, tx, rent, ""); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO rentals (`+rentalColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			rent.ID, rent.ToolID, rent.RenterID, rent.Start.UTC(), rent.End.UTC(), rent.Status,
+			rent.CreatedAt.UTC(), rent.UpdatedAt.UTC(),
+		)
+		if uniqueViolationOn(err, "rentals_pkey") {
+			return rental.ErrRentalExists
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create rental: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Update updates an existing rental
+func (r *RentalRepository) Update(ctx context.Context, rent *rental.Rental, expected rental.Status) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := checkConflicts(ctx, tx, rent, false); err != nil {
+			return err
+		}
+		if err := checkBlocks(ctx, tx, rent, expected); err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`UPDATE rentals SET tool_id = $2, renter_id = $3, start_at = $4, end_at = $5,
+				status = $6, updated_at = $7
+			WHERE id = $1 AND status = $8`,
+			rent.ID, rent.ToolID, rent.RenterID, rent.Start.UTC(), rent.End.UTC(), rent.Status,
+			rent.UpdatedAt.UTC(), expected,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update rental: %w", err)
+		}
+
+		return requireTransition(ctx, tx, result, rent.ID)
+	})
+}
+
+// checkConflicts locks the rental's tool for the rest of the transaction and
+// rejects the rental if it overlaps a blocking rental of the same tool
+func checkConflicts(ctx context.Context, tx *sql.Tx, rent *rental.Rental, isNew bool) error {
+	if err := lockTool(ctx, tx, rent.ToolID); err != nil {
+		return err
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT `+rentalColumns+` FROM rentals
+		WHERE tool_id = $1 AND status IN ($2, $3) AND start_at < $4 AND end_at > $5`,
+		rent.ToolID, rental.StatusConfirmed, rental.StatusPickedUp, rent.End.UTC(), rent.Start.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check rental conflicts: %w", err)
+	}
+
+	existing, err := scanRentals(rows)
+	if err != nil {
+		return err
+	}
+
+	return rent.CheckConflicts(existing, isNew)
+}
+
+// checkBlocks rejects the rental if it overlaps a block of its tool. It must
+// run after checkConflicts, which locks the tool; previous is the status the
+// rental is stored with, empty for a new rental.
+func checkBlocks(ctx context.Context, tx *sql.Tx, rent *rental.Rental, previous rental.Status) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT `+blockColumns+` FROM tool_blocks WHERE tool_id = $1 AND start_at < $2 AND end_at > $3`,
+		rent.ToolID, rent.End.UTC(), rent.Start.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check tool blocks: %w", err)
+	}
+
+	blocks, err := scanBlocks(rows)
+	if err != nil {
+		return err
+	}
+
+	return rent.CheckBlocks(blocks, previous)
+}
+
+// lockTool takes the transaction-scoped advisory lock that serializes the
+// bookings and blocks of a tool
+func lockTool(ctx context.Context, tx *sql.Tx, toolID string) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, toolID); err != nil {
+		return fmt.Errorf("failed to lock tool: %w", err)
+	}
+	return nil
+}
+
+// requireTransition reports why a conditional rental update matched no row:
+// either the rental does not exist or its status changed since it was loaded
+func requireTransition(ctx context.Context, tx *sql.Tx, result sql.Result, id string) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM rentals WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to update rental: %w", err)
+	}
+	if !exists {
+		return rental.ErrRentalNotFound
+	}
+	return fmt.Errorf("%w: rental was changed concurrently", rental.ErrInvalidTransition)
+}
+
+func scanRentals(rows *sql.Rows) ([]*rental.Rental, error) {
+	defer rows.Close()
+
+	rentals := make([]*rental.Rental, 0)
+	for rows.Next() {
+		rent, err := scanRental(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load rental: %w", err)
+		}
+		rentals = append(rentals, rent)
+	}
+
+	return rentals, rows.Err()
+}
+
+func scanRental(s scanner) (*rental.Rental, error) {
+	var rent rental.Rental
+	err := s.Scan(
+		&rent.ID, &rent.ToolID, &rent.RenterID, &rent.Start, &rent.End, &rent.Status,
+		&rent.CreatedAt, &rent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rent, nil
+}
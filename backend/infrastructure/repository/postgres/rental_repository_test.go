@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// day returns midnight UTC of the given day in January 2030
+func day(d int) time.Time {
+	return time.Date(2030, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+// TestRentalRepositoryConcurrentCreates stores overlapping confirmed rentals
+// of the same tool from separate connections at once; the advisory lock on
+// the tool must let exactly one of them through
+func TestRentalRepositoryConcurrentCreates(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := NewRentalRepository(db)
+
+	const rentals = 10
+	db.SetMaxOpenConns(rentals)
+
+	var wg sync.WaitGroup
+	errs := make([]error, rentals)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rent := rental.NewRental(fmt.Sprintf("r%d", i), "drill", "renter", day(1+i%3), day(5+i%3))
+			rent.Status = rental.StatusConfirmed
+			errs[i] = repo.Create(ctx, rent)
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, rental.ErrConflict):
+			t.Errorf("Create(r%d) = %v, want nil or ErrConflict", i, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d overlapping rentals created, want exactly 1", created)
+	}
+
+	// Another tool is not held up by the drill's rentals
+	saw := rental.NewRental("saw", "saw", "renter", day(1), day(5))
+	saw.Status = rental.StatusConfirmed
+	if err := repo.Create(ctx, saw); err != nil {
+		t.Errorf("Create(saw) = %v", err)
+	}
+}
+
+func TestRentalRepositoryUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		next     rental.Status
+		expected rental.Status
+		want     error
+	}{
+		{"expected status", rental.StatusConfirmed, rental.StatusRequested, nil},
+		{"changed concurrently", rental.StatusConfirmed, rental.StatusConfirmed, rental.ErrInvalidTransition},
+		{"confirming over confirmed", rental.StatusConfirmed, rental.StatusRequested, rental.ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewRentalRepository(openTestDB(t))
+
+			rent := rental.NewRental("r1", "drill", "renter", day(1), day(5))
+			if err := repo.Create(ctx, rent); err != nil {
+				t.Fatalf("Create() = %v", err)
+			}
+			if tt.want == rental.ErrConflict {
+				other := rental.NewRental("r2", "drill", "renter", day(4), day(9))
+				other.Status = rental.StatusConfirmed
+				if err := repo.Create(ctx, other); err != nil {
+					t.Fatalf("Create(other) = %v", err)
+				}
+			}
+
+			rent.Status = tt.next
+			if err := repo.Update(ctx, rent, tt.expected); !errors.Is(err, tt.want) {
+				t.Errorf("Update() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalRepositoryBlocks(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := NewRentalRepository(db)
+
+	requested := rental.NewRental("requested", "drill", "renter", day(1), day(5))
+	if err := repo.Create(ctx, requested); err != nil {
+		t.Fatalf("Create(requested) = %v", err)
+	}
+
+	block := tool.NewBlock("b1", "drill", tool.BlockMaintenance, day(3), day(6), "")
+	if err := NewToolBlockRepository(db).Create(ctx, block); err != nil {
+		t.Fatalf("Create(block) = %v", err)
+	}
+
+	if err := repo.Create(ctx, rental.NewRental("new", "drill", "renter", day(2), day(4))); !errors.Is(err, rental.ErrToolBlocked) {
+		t.Errorf("Create() over the block = %v, want ErrToolBlocked", err)
+	}
+
+	requested.Status = rental.StatusConfirmed
+	if err := repo.Update(ctx, requested, rental.StatusRequested); !errors.Is(err, rental.ErrToolBlocked) {
+		t.Errorf("Update() confirming over the block = %v, want ErrToolBlocked", err)
+	}
+}
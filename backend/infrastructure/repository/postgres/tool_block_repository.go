@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolBlockRepository implements tool.BlockRepository interface using PostgreSQL
+type ToolBlockRepository struct {
+	db *sql.DB
+}
+
+// NewToolBlockRepository creates a new PostgreSQL tool block repository
+func NewToolBlockRepository(db *sql.DB) *ToolBlockRepository {
+	return &ToolBlockRepository{db: db}
+}
+
+const blockColumns = `id, tool_id, kind, start_at, end_at, reason, created_at`
+
+// FindByToolID retrieves every block of a tool, ordered by start date
+func (r *ToolBlockRepository) FindByToolID(ctx context.Context, toolID string) ([]*tool.Block, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+blockColumns+` FROM tool_blocks WHERE tool_id = $1 ORDER BY start_at, id`,
+		toolID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool blocks: %w", err)
+	}
+
+	return scanBlocks(rows)
+}
+
+// Create creates a new block, waiting for bookings of the tool that are
+// being stored (see RentalRepository)
+func (r *ToolBlockRepository) Create(ctx context.Context, b *tool.Block) error {
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := lockTool(ctx, tx, b.ToolID); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO tool_blocks (`+blockColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			b.ID, b.ToolID, b.Kind, b.Start.UTC(), b.End.UTC(), b.Reason, b.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create tool block: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a block by its ID
+func (r *ToolBlockRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tool_blocks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tool block: %w", err)
+	}
+
+	return requireRow(result, tool.ErrBlockNotFound)
+}
+
+func scanBlocks(rows *sql.Rows) ([]*tool.Block, error) {
+	defer rows.Close()
+
+	blocks := make([]*tool.Block, 0)
+	for rows.Next() {
+		var b tool.Block
+		if err := rows.Scan(&b.ID, &b.ToolID, &b.Kind, &b.Start, &b.End, &b.Reason, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to load tool block: %w", err)
+		}
+		blocks = append(blocks, &b)
+	}
+
+	return blocks, rows.Err()
+}
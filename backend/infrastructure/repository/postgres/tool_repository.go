@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/toolrentalclub/domain/tool"
+)
+
+// ToolRepository implements tool.Repository interface using PostgreSQL
+type ToolRepository struct {
+	db *sql.DB
+}
+
+// NewToolRepository creates a new PostgreSQL tool repository
+func NewToolRepository(db *sql.DB) *ToolRepository {
+	return &ToolRepository{db: db}
+}
+
+const toolColumns = `id, name, description, category, condition, replacement_value_cents,
+	daily_rate_cents, owner_id, status, created_at, updated_at`
+
+// FindByID retrieves a tool by its ID
+func (r *ToolRepository) FindByID(ctx context.Context, id string) (*tool.Tool, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+toolColumns+` FROM tools WHERE id = $1`, id)
+
+	t, err := scanTool(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, tool.ErrToolNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tool: %w", err)
+	}
+
+	return t, nil
+}
+
+// FindAll retrieves every tool, oldest first
+func (r *ToolRepository) FindAll(ctx context.Context) ([]*tool.Tool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+toolColumns+` FROM tools ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+	defer rows.Close()
+
+	tools := make([]*tool.Tool, 0)
+	for rows.Next() {
+		t, err := scanTool(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tool: %w", err)
+		}
+		tools = append(tools, t)
+	}
+
+	return tools, rows.Err()
+}
+
+// Create creates a new tool
+func (r *ToolRepository) Create(ctx context.Context, t *tool.Tool) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO tools (`+toolColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		t.ID, t.Name, t.Description, t.Category, t.Condition, t.ReplacementValueCents,
+		t.DailyRateCents, t.OwnerID, t.Status, t.CreatedAt.UTC(), t.UpdatedAt.UTC(),
+	)
+	if uniqueViolationOn(err, "tools_pkey") {
+		return tool.ErrToolExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create tool: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing tool
+func (r *ToolRepository) Update(ctx context.Context, t *tool.Tool) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tools SET name = $2, description = $3, category = $4, condition = $5,
+			replacement_value_cents = $6, daily_rate_cents = $7, owner_id = $8, status = $9,
+			updated_at = $10
+		WHERE id = $1`,
+		t.ID, t.Name, t.Description, t.Category, t.Condition, t.ReplacementValueCents,
+		t.DailyRateCents, t.OwnerID, t.Status, t.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tool: %w", err)
+	}
+
+	return requireRow(result, tool.ErrToolNotFound)
+}
+
+// Delete removes a tool by its ID
+func (r *ToolRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tools WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tool: %w", err)
+	}
+
+	return requireRow(result, tool.ErrToolNotFound)
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTool(s scanner) (*tool.Tool, error) {
+	var t tool.Tool
+	err := s.Scan(
+		&t.ID, &t.Name, &t.Description, &t.Category, &t.Condition, &t.ReplacementValueCents,
+		&t.DailyRateCents, &t.OwnerID, &t.Status, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// UserRepository implements user.Repository interface using PostgreSQL.
+// Email uniqueness is enforced by the users_email_key unique index.
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new PostgreSQL user repository
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// userColumns lists the columns of a user, ending with statusColumns and
+// profileColumns
+const userColumns = `id, email, email_verified, roles, created_at, updated_at, ` + statusColumns + `, ` + profileColumns
+
+const statusColumns = `status, status_reason, suspended_until`
+
+const profileColumns = `display_name, phone,
+	address_line1, address_line2, address_city, address_region, address_postal_code, address_country,
+	avatar_url, bio, notify_rental_updates, notify_reminders, notify_sms, notify_newsletter`
+
+// FindByID retrieves a user by their ID
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+	return scanUser(row)
+}
+
+// FindByEmail retrieves a user by their email
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1 AND email <> ''`, email)
+	return scanUser(row)
+}
+
+// Create creates a new user
+func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO users (`+userColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+		append(append([]any{u.ID, u.Email, u.EmailVerified, formatRoles(u.Roles), u.CreatedAt.UTC(), u.UpdatedAt.UTC()}, statusArgs(u)...), profileArgs(u.Profile)...)...,
+	)
+	switch {
+	case uniqueViolationOn(err, "users_pkey"):
+		return user.ErrUserExists
+	case uniqueViolationOn(err, "users_email_key"):
+		return user.ErrEmailTaken
+	case err != nil:
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing user
+func (r *UserRepository) Update(ctx context.Context, u *user.User) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET email = $2, email_verified = $3, roles = $4, updated_at = $5,
+			status = $6, status_reason = $7, suspended_until = $8,
+			display_name = $9, phone = $10,
+			address_line1 = $11, address_line2 = $12, address_city = $13,
+			address_region = $14, address_postal_code = $15, address_country = $16,
+			avatar_url = $17, bio = $18,
+			notify_rental_updates = $19, notify_reminders = $20, notify_sms = $21, notify_newsletter = $22
+		WHERE id = $1`,
+		append(append([]any{u.ID, u.Email, u.EmailVerified, formatRoles(u.Roles), u.UpdatedAt.UTC()}, statusArgs(u)...), profileArgs(u.Profile)...)...,
+	)
+	if uniqueViolationOn(err, "users_email_key") {
+		return user.ErrEmailTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return requireRow(result, user.ErrUserNotFound)
+}
+
+// List retrieves a page of the users matching the query. The cursor is
+// applied as a keyset condition on the sort column and ID, which the
+// ORDER BY matches, so pages stay stable while users are added.
+func (r *UserRepository) List(ctx context.Context, query user.ListQuery) (*user.ListPage, error) {
+	cursor, err := query.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.EmailPrefix != "" {
+		conditions = append(conditions, `lower(email) LIKE `+arg(escapeLike(query.EmailPrefix)+"%")+` ESCAPE '\'`)
+	}
+	if query.Role != "" && query.Role != user.RoleMember {
+		conditions = append(conditions, `',' || roles || ',' LIKE `+arg("%,"+string(query.Role)+",%"))
+	}
+	if query.Status != "" {
+		conditions = append(conditions, `status = `+arg(string(query.Status)))
+	} else {
+		conditions = append(conditions, `status <> `+arg(string(user.StatusDeleted)))
+	}
+	if !query.CreatedAfter.IsZero() {
+		conditions = append(conditions, `created_at >= `+arg(query.CreatedAfter.UTC()))
+	}
+	if !query.CreatedBefore.IsZero() {
+		conditions = append(conditions, `created_at < `+arg(query.CreatedBefore.UTC()))
+	}
+
+	column, direction, comparison := "created_at", "ASC", ">"
+	if query.Sort == user.SortByEmail {
+		column = "email"
+	}
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if cursor != nil {
+		var key any = cursor.CreatedAt.UTC()
+		if query.Sort == user.SortByEmail {
+			key = cursor.Email
+		}
+		conditions = append(conditions, `(`+column+`, id) `+comparison+` (`+arg(key)+`, `+arg(cursor.ID)+`)`)
+	}
+
+	sqlQuery := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		sqlQuery += ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+	sqlQuery += ` ORDER BY ` + column + ` ` + direction + `, id ` + direction + ` LIMIT ` + arg(query.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*user.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	page := &user.ListPage{Users: users}
+	if len(users) > query.Limit {
+		page.Users = users[:query.Limit]
+		page.NextCursor = query.CursorAfter(page.Users[query.Limit-1])
+	}
+	return page, nil
+}
+
+func scanUser(row scanner) (*user.User, error) {
+	var u user.User
+	var roles, status string
+	var suspendedUntil sql.NullTime
+	p := &u.Profile
+	err := row.Scan(
+		&u.ID, &u.Email, &u.EmailVerified, &roles, &u.CreatedAt, &u.UpdatedAt,
+		&status, &u.StatusReason, &suspendedUntil,
+		&p.DisplayName, &p.Phone,
+		&p.Address.Line1, &p.Address.Line2, &p.Address.City,
+		&p.Address.Region, &p.Address.PostalCode, &p.Address.Country,
+		&p.AvatarURL, &p.Bio,
+		&p.Notifications.RentalUpdates, &p.Notifications.Reminders, &p.Notifications.SMS, &p.Notifications.Newsletter,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	u.Roles = parseRoles(roles)
+	u.Status = user.Status(status)
+	if suspendedUntil.Valid {
+		u.SuspendedUntil = suspendedUntil.Time
+	}
+
+	return &u, nil
+}
+
+// statusArgs lists the values of statusColumns for u, in order
+func statusArgs(u *user.User) []any {
+	until := sql.NullTime{Time: u.SuspendedUntil.UTC(), Valid: !u.SuspendedUntil.IsZero()}
+	return []any{string(u.Status), u.StatusReason, until}
+}
+
+// profileArgs lists the values of profileColumns for p, in order
+func profileArgs(p user.Profile) []any {
+	return []any{
+		p.DisplayName, p.Phone,
+		p.Address.Line1, p.Address.Line2, p.Address.City,
+		p.Address.Region, p.Address.PostalCode, p.Address.Country,
+		p.AvatarURL, p.Bio,
+		p.Notifications.RentalUpdates, p.Notifications.Reminders, p.Notifications.SMS, p.Notifications.Newsletter,
+	}
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern with a backslash
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// formatRoles encodes roles for the roles column
+func formatRoles(roles []user.Role) string {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = string(r)
+	}
+	return strings.Join(names, ",")
+}
+
+// parseRoles decodes the roles column
+func parseRoles(value string) []user.Role {
+	if value == "" {
+		return nil
+	}
+	return user.ParseRoles(strings.Split(value, ","))
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+func TestUserRepositoryEmailUniqueness(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(ctx context.Context, repo *UserRepository) error
+		want   error
+	}{
+		{
+			name: "create with taken email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u3", "dora@example.com"))
+			},
+			want: user.ErrEmailTaken,
+		},
+		{
+			name: "create with taken ID",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				return repo.Create(ctx, user.NewUser("u1", "new@example.com"))
+			},
+			want: user.ErrUserExists,
+		},
+		{
+			name: "users without email",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				if err := repo.Create(ctx, user.NewUser("u3", "")); err != nil {
+					return err
+				}
+				return repo.Create(ctx, user.NewUser("u4", ""))
+			},
+		},
+		{
+			name: "email changed to a taken one",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, err := repo.FindByID(ctx, "u1")
+				if err != nil {
+					return err
+				}
+				u.Email = "bob@example.com"
+				return repo.Update(ctx, u)
+			},
+			want: user.ErrEmailTaken,
+		},
+		{
+			name: "email freed by a change",
+			change: func(ctx context.Context, repo *UserRepository) error {
+				u, err := repo.FindByID(ctx, "u1")
+				if err != nil {
+					return err
+				}
+				u.Email = "dora@new.example.com"
+				if err := repo.Update(ctx, u); err != nil {
+					return err
+				}
+				return repo.Create(ctx, user.NewUser("u3", "dora@example.com"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewUserRepository(openTestDB(t))
+			for _, u := range []*user.User{user.NewUser("u1", "dora@example.com"), user.NewUser("u2", "bob@example.com")} {
+				if err := repo.Create(ctx, u); err != nil {
+					t.Fatalf("Create() = %v", err)
+				}
+			}
+
+			if err := tt.change(ctx, repo); !errors.Is(err, tt.want) {
+				t.Errorf("change returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestUserRepositoryList pages through users created a day apart, with an
+// email ordering that differs from their creation order, and checks the
+// listing matches the in-memory keyset pagination of user.Paginate
+func TestUserRepositoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewUserRepository(openTestDB(t))
+
+	base := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	users := []*user.User{
+		{ID: "a", Email: "dora@example.com", CreatedAt: base, Status: user.StatusActive},
+		{ID: "b", Email: "bob@example.com", CreatedAt: base.Add(24 * time.Hour), Status: user.StatusActive, Roles: []user.Role{user.RoleStaff}},
+		{ID: "c", Email: "carol@example.com", CreatedAt: base.Add(48 * time.Hour), Status: user.StatusSuspended},
+		{ID: "d", Email: "alice@example.com", CreatedAt: base.Add(48 * time.Hour), Status: user.StatusActive},
+		{ID: "e", Email: "erased@example.com", CreatedAt: base.Add(72 * time.Hour), Status: user.StatusDeleted},
+	}
+	for _, u := range users {
+		u.UpdatedAt = u.CreatedAt
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create(%s) = %v", u.ID, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query user.ListQuery
+		count int // users listed
+	}{
+		{"created ascending by pages", user.ListQuery{Limit: 1}, 4},
+		{"created descending by pages", user.ListQuery{Descending: true, Limit: 3}, 4},
+		{"email ascending by pages", user.ListQuery{Sort: user.SortByEmail, Limit: 2}, 4},
+		{"email descending by pages", user.ListQuery{Sort: user.SortByEmail, Descending: true, Limit: 2}, 4},
+		{"ties broken by ID", user.ListQuery{CreatedAfter: users[2].CreatedAt, Limit: 1}, 2},
+		{"email prefix ignores case", user.ListQuery{EmailPrefix: "BO"}, 1},
+		{"email prefix with a wildcard", user.ListQuery{EmailPrefix: "%"}, 0},
+		{"role", user.ListQuery{Role: user.RoleStaff}, 1},
+		{"status", user.ListQuery{Status: user.StatusSuspended}, 1},
+		{"deleted only when asked for", user.ListQuery{Status: user.StatusDeleted}, 1},
+		{"created range", user.ListQuery{CreatedAfter: users[1].CreatedAt, CreatedBefore: users[2].CreatedAt}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listAll(t, tt.query, func(q user.ListQuery) (*user.ListPage, error) {
+				return repo.List(ctx, q)
+			})
+			want := listAll(t, tt.query, func(q user.ListQuery) (*user.ListPage, error) {
+				return user.Paginate(users, q)
+			})
+			if len(want) != tt.count {
+				t.Fatalf("Paginate() listed %v, want %d users", want, tt.count)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("listed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// listAll follows the cursors of list and returns the IDs in listing order
+func listAll(t *testing.T, q user.ListQuery, list func(user.ListQuery) (*user.ListPage, error)) []string {
+	t.Helper()
+
+	var ids []string
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("listing does not end")
+		}
+		page, err := list(q)
+		if err != nil {
+			t.Fatalf("List() = %v", err)
+		}
+		for _, u := range page.Users {
+			ids = append(ids, u.ID)
+		}
+		if page.NextCursor == "" {
+			return ids
+		}
+		q.Cursor = page.NextCursor
+	}
+}
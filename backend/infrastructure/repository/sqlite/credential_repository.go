@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+)
+
+// CredentialRepository implements auth.CredentialRepository interface using SQLite
+type CredentialRepository struct {
+	db *sql.DB
+}
+
+// NewCredentialRepository creates a new SQLite credential repository
+func NewCredentialRepository(db *sql.DB) *CredentialRepository {
+	return &CredentialRepository{db: db}
+}
+
+const credentialColumns = `user_id, email, password_hash, created_at, updated_at`
+
+// FindByUserID retrieves the credential of a user
+func (r *CredentialRepository) FindByUserID(ctx context.Context, userID string) (*auth.Credential, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM credentials WHERE user_id = ?`, userID)
+	return scanCredential(row)
+}
+
+// FindByEmail retrieves a credential by login email
+func (r *CredentialRepository) FindByEmail(ctx context.Context, email string) (*auth.Credential, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+credentialColumns+` FROM credentials WHERE email = ?`, email)
+	return scanCredential(row)
+}
+
+// Create creates a new credential
+func (r *CredentialRepository) Create(ctx context.Context, c *auth.Credential) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO credentials (`+credentialColumns+`) VALUES (?, ?, ?, ?, ?)`,
+		c.UserID, c.Email, c.PasswordHash, c.CreatedAt.UTC(), c.UpdatedAt.UTC(),
+	)
+	if uniqueViolationOn(err, "credentials.user_id") || uniqueViolationOn(err, "credentials.email") {
+		return auth.ErrEmailTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create credential: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing credential
+func (r *CredentialRepository) Update(ctx context.Context, c *auth.Credential) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE credentials SET email = ?, password_hash = ?, updated_at = ? WHERE user_id = ?`,
+		c.Email, c.PasswordHash, c.UpdatedAt.UTC(), c.UserID,
+	)
+	if uniqueViolationOn(err, "credentials.email") {
+		return auth.ErrEmailTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update credential: %w", err)
+	}
+
+	return requireRow(result, auth.ErrCredentialNotFound)
+}
+
+// Delete removes the credential of a user
+func (r *CredentialRepository) Delete(ctx context.Context, userID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM credentials WHERE user_id = ?`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	return requireRow(result, auth.ErrCredentialNotFound)
+}
+
+func scanCredential(row *sql.Row) (*auth.Credential, error) {
+	var c auth.Credential
+	err := row.Scan(&c.UserID, &c.Email, &c.PasswordHash, &c.CreatedAt, &c.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, auth.ErrCredentialNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential: %w", err)
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,170 @@
+// Package tokencache decorates an auth.Service to remember verified tokens
+// until they expire, so that each token is verified with the identity
+// provider once rather than on every request.
+package tokencache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// Service is an auth.Service that caches the tokens verified by another one.
+// It holds at most size tokens and evicts the least recently used first.
+// Tokens are keyed by their SHA-256 hash and stored without their value, so
+// the cache never holds them in a form that could be replayed. Failed
+// verifications are not cached.
+type Service struct {
+	next    auth.Service
+	size    int
+	metrics *metrics.Metrics
+	now     func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+// entry is a cached token, whose Value is always empty; elements of
+// Service.order hold entries
+type entry struct {
+	key   [sha256.Size]byte
+	token auth.Token
+}
+
+// New creates a cache of at most size verified tokens in front of next
+func New(next auth.Service, size int, m *metrics.Metrics) *Service {
+	return &Service{
+		next:    next,
+		size:    size,
+		metrics: m,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[[sha256.Size]byte]*list.Element, size),
+	}
+}
+
+// VerifyToken returns the cached token if it has been verified before and has
+// not expired, and verifies it with the wrapped service otherwise
+func (s *Service) VerifyToken(ctx context.Context, tokenValue string) (*auth.Token, error) {
+	key := sha256.Sum256([]byte(tokenValue))
+
+	if token, ok := s.get(key); ok {
+		s.metrics.ObserveTokenCache(true)
+		token.Value = tokenValue
+		return token, nil
+	}
+	s.metrics.ObserveTokenCache(false)
+
+	token, err := s.next.VerifyToken(ctx, tokenValue)
+	if err != nil {
+		return nil, err
+	}
+
+	s.put(key, token)
+	return token, nil
+}
+
+// VerifyTokenAndCheckRevoked always asks the wrapped service, bypassing the
+// cache, and drops a revoked token from it. When the wrapped service cannot
+// check revocation this is the same as VerifyToken.
+func (s *Service) VerifyTokenAndCheckRevoked(ctx context.Context, tokenValue string) (*auth.Token, error) {
+	checker, ok := s.next.(auth.RevocationChecker)
+	if !ok {
+		return s.VerifyToken(ctx, tokenValue)
+	}
+
+	key := sha256.Sum256([]byte(tokenValue))
+	token, err := checker.VerifyTokenAndCheckRevoked(ctx, tokenValue)
+	if err != nil {
+		if errors.Is(err, auth.ErrTokenRevoked) {
+			s.remove(key)
+		}
+		return nil, err
+	}
+
+	s.put(key, token)
+	return token, nil
+}
+
+// get returns a copy of the cached token, dropping it if it has expired
+func (s *Service) get(key [sha256.Size]byte) (*auth.Token, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if !s.now().Before(e.token.ExpiresAt) {
+		s.order.Remove(el)
+		delete(s.entries, key)
+		return nil, false
+	}
+
+	s.order.MoveToFront(el)
+	token := e.token
+	return &token, true
+}
+
+// put caches a copy of the token without its value unless it has no expiry
+// or has already expired, evicting the least recently used token when the
+// cache is full
+func (s *Service) put(key [sha256.Size]byte, verified *auth.Token) {
+	if !s.now().Before(verified.ExpiresAt) {
+		return
+	}
+
+	token := *verified
+	token.Value = ""
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*entry).token = token
+		s.order.MoveToFront(el)
+		return
+	}
+
+	for s.order.Len() >= s.size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*entry).key)
+	}
+
+	s.entries[key] = s.order.PushFront(&entry{key: key, token: token})
+}
+
+// Close empties the cache, so that verified tokens do not outlive the
+// server, and closes the wrapped service if it holds resources
+func (s *Service) Close(ctx context.Context) error {
+	s.mu.Lock()
+	s.order.Init()
+	clear(s.entries)
+	s.mu.Unlock()
+
+	if c, ok := s.next.(interface{ Close(context.Context) error }); ok {
+		return c.Close(ctx)
+	}
+	return nil
+}
+
+// remove drops a token from the cache
+func (s *Service) remove(key [sha256.Size]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+}
@@ -0,0 +1,175 @@
+package tokencache
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+var start = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// fakeService verifies every token as its own user, expiring an hour after
+// start, and counts the verifications. Tokens named "expired" have already
+// expired and "revoked" tokens fail the revocation check.
+type fakeService struct {
+	calls map[string]int
+}
+
+func (f *fakeService) VerifyToken(_ context.Context, tokenValue string) (*auth.Token, error) {
+	f.calls[tokenValue]++
+	if tokenValue == "invalid" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	token := auth.NewToken(tokenValue, "user-"+tokenValue, tokenValue+"@example.com")
+	token.ExpiresAt = start.Add(time.Hour)
+	if tokenValue == "expired" {
+		token.ExpiresAt = start
+	}
+	return token, nil
+}
+
+func (f *fakeService) VerifyTokenAndCheckRevoked(ctx context.Context, tokenValue string) (*auth.Token, error) {
+	if tokenValue == "revoked" {
+		f.calls[tokenValue]++
+		return nil, auth.ErrTokenRevoked
+	}
+	return f.VerifyToken(ctx, tokenValue)
+}
+
+func TestServiceVerifyToken(t *testing.T) {
+	// step verifies a token, after advancing the clock by wait
+	type step struct {
+		wait  time.Duration
+		token string
+		calls int // verifications of the token by the wrapped service so far
+	}
+
+	tests := []struct {
+		name  string
+		size  int
+		steps []step
+	}{
+		{
+			name: "cached until it expires",
+			size: 2,
+			steps: []step{
+				{token: "a", calls: 1},
+				{token: "a", calls: 1},
+				{wait: 59 * time.Minute, token: "a", calls: 1},
+				{wait: time.Minute, token: "a", calls: 2},
+			},
+		},
+		{
+			name: "least recently used evicted",
+			size: 2,
+			steps: []step{
+				{token: "a", calls: 1},
+				{token: "b", calls: 1},
+				{token: "a", calls: 1}, // a is now more recent than b
+				{token: "c", calls: 1}, // evicts b
+				{token: "a", calls: 1},
+				{token: "b", calls: 2},
+			},
+		},
+		{
+			name: "expired tokens not cached",
+			size: 2,
+			steps: []step{
+				{token: "expired", calls: 1},
+				{token: "expired", calls: 2},
+			},
+		},
+		{
+			name: "failures not cached",
+			size: 2,
+			steps: []step{
+				{token: "invalid", calls: 1},
+				{token: "invalid", calls: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			next := &fakeService{calls: make(map[string]int)}
+			cache := New(next, tt.size, metrics.New())
+			cache.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.wait)
+				token, err := cache.VerifyToken(context.Background(), s.token)
+				if err == nil && (token.Value != s.token || token.UserID != "user-"+s.token) {
+					t.Errorf("step %d: VerifyToken(%q) = token %q of %q", i, s.token, token.Value, token.UserID)
+				}
+				if got := next.calls[s.token]; got != s.calls {
+					t.Errorf("step %d: %q verified %d times, want %d", i, s.token, got, s.calls)
+				}
+				if cache.order.Len() > tt.size {
+					t.Errorf("step %d: cache holds %d tokens, size is %d", i, cache.order.Len(), tt.size)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceStoresTokensWithoutValue(t *testing.T) {
+	cache := New(&fakeService{calls: make(map[string]int)}, 1, metrics.New())
+	cache.now = func() time.Time { return start }
+
+	if _, err := cache.VerifyToken(context.Background(), "secret"); err != nil {
+		t.Fatalf("VerifyToken() = %v", err)
+	}
+	for el := cache.order.Front(); el != nil; el = el.Next() {
+		if value := el.Value.(*entry).token.Value; value != "" {
+			t.Errorf("cached token holds its value %q", value)
+		}
+	}
+
+	// Changing a returned token must not change the cached copy
+	token, _ := cache.VerifyToken(context.Background(), "secret")
+	token.UserID = "someone-else"
+	token, _ = cache.VerifyToken(context.Background(), "secret")
+	if token.UserID != "user-secret" || token.Value != "secret" {
+		t.Errorf("cached token = %q of %q, want %q of %q", token.Value, token.UserID, "secret", "user-secret")
+	}
+}
+
+func TestServiceDropsRevokedTokens(t *testing.T) {
+	next := &fakeService{calls: make(map[string]int)}
+	cache := New(next, 2, metrics.New())
+	cache.now = func() time.Time { return start }
+
+	// Cache the token, as if verified before it was revoked
+	cache.put(sha256.Sum256([]byte("revoked")), &auth.Token{UserID: "user-revoked", ExpiresAt: start.Add(time.Hour)})
+
+	if _, err := cache.VerifyTokenAndCheckRevoked(context.Background(), "revoked"); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("VerifyTokenAndCheckRevoked() = %v, want ErrTokenRevoked", err)
+	}
+	if _, err := cache.VerifyToken(context.Background(), "revoked"); err != nil {
+		t.Fatalf("VerifyToken() = %v", err)
+	}
+	if next.calls["revoked"] != 2 {
+		t.Errorf("revoked token verified %d times, want 2: it should have left the cache", next.calls["revoked"])
+	}
+}
+
+func TestServiceClose(t *testing.T) {
+	cache := New(&fakeService{calls: make(map[string]int)}, 2, metrics.New())
+	cache.now = func() time.Time { return start }
+
+	if _, err := cache.VerifyToken(context.Background(), "a"); err != nil {
+		t.Fatalf("VerifyToken() = %v", err)
+	}
+	if err := cache.Close(context.Background()); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if cache.order.Len() != 0 || len(cache.entries) != 0 {
+		t.Errorf("cache holds %d tokens after Close", cache.order.Len())
+	}
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// RoleRequest represents a request to grant a role
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+// UserRolesResponse represents a user's roles and the permissions they grant
+type UserRolesResponse struct {
+	UserID      string   `json:"userId"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// StatusRequest represents a request to suspend or close an account.
+// Until ends a suspension; it is omitted for indefinite suspensions.
+type StatusRequest struct {
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+// UserSummaryResponse represents a user in the admin user directory
+type UserSummaryResponse struct {
+	UserID         string     `json:"userId"`
+	Email          string     `json:"email"`
+	DisplayName    string     `json:"displayName"`
+	Roles          []string   `json:"roles"`
+	Status         string     `json:"status"`
+	StatusReason   string     `json:"statusReason,omitempty"`
+	SuspendedUntil *time.Time `json:"suspendedUntil,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// UserListResponse represents a page of the admin user directory. Next is
+// the URL of the following page and NextCursor its cursor; both are omitted
+// on the last page.
+type UserListResponse struct {
+	Users      []UserSummaryResponse `json:"users"`
+	NextCursor string                `json:"nextCursor,omitempty"`
+	Next       string                `json:"next,omitempty"`
+}
@@ -13,8 +13,3 @@ type VerifyTokenResponse struct {
 	Email   string `json:"email,omitempty"`
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
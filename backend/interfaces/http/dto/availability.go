@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// AvailabilityInterval represents a free or busy period of a tool
+type AvailabilityInterval struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Status  string    `json:"status"`
+	Reasons []string  `json:"reasons,omitempty"`
+}
+
+// AvailabilityResponse represents a tool's availability calendar
+type AvailabilityResponse struct {
+	ToolID    string                 `json:"toolId"`
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Intervals []AvailabilityInterval `json:"intervals"`
+}
+
+// BlockRequest represents the request body to block a tool for a period
+type BlockRequest struct {
+	Kind   string    `json:"kind"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// BlockResponse represents a maintenance or blackout block
+type BlockResponse struct {
+	ID        string    `json:"id"`
+	ToolID    string    `json:"toolId"`
+	Kind      string    `json:"kind"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BlockListResponse represents a list of blocks
+type BlockListResponse struct {
+	Blocks []BlockResponse `json:"blocks"`
+}
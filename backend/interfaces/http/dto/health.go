@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// HealthCheckResponse represents a health check response
+type HealthCheckResponse struct {
+	Status     string                    `json:"status"`
+	Message    string                    `json:"message"`
+	Components []ComponentHealthResponse `json:"components,omitempty"`
+}
+
+// ComponentHealthResponse represents the latest check of one dependency
+type ComponentHealthResponse struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Critical    bool       `json:"critical"`
+	LatencyMs   float64    `json:"latencyMs"`
+	CheckedAt   time.Time  `json:"checkedAt"`
+	LastError   string     `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
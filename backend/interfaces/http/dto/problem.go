@@ -0,0 +1,26 @@
+package dto
+
+// ProblemResponse is an RFC 7807 problem details object, served as
+// application/problem+json for every error
+type ProblemResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Code is a stable, machine-readable error code such as "tool_not_found"
+	Code string `json:"code"`
+
+	// Errors lists the invalid fields of a validation error
+	Errors []FieldErrorResponse `json:"errors,omitempty"`
+
+	// RequestID identifies the request in the server's logs
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// FieldErrorResponse describes why one request field is invalid
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
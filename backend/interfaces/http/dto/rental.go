@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// CreateRentalRequest represents the request body to reserve a tool
+type CreateRentalRequest struct {
+	ToolID string    `json:"toolId"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+// RentalResponse represents a rental in API responses
+type RentalResponse struct {
+	ID        string    `json:"id"`
+	ToolID    string    `json:"toolId"`
+	RenterID  string    `json:"renterId"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
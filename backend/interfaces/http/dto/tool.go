@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// ToolRequest represents the request body to create or update a tool
+type ToolRequest struct {
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	Category              string `json:"category"`
+	Condition             string `json:"condition"`
+	ReplacementValueCents int64  `json:"replacementValueCents"`
+	DailyRateCents        int64  `json:"dailyRateCents"`
+	Status                string `json:"status,omitempty"`
+}
+
+// ToolResponse represents a tool in API responses
+type ToolResponse struct {
+	ID                    string    `json:"id"`
+	Name                  string    `json:"name"`
+	Description           string    `json:"description"`
+	Category              string    `json:"category"`
+	Condition             string    `json:"condition"`
+	ReplacementValueCents int64     `json:"replacementValueCents"`
+	DailyRateCents        int64     `json:"dailyRateCents"`
+	OwnerID               string    `json:"ownerId"`
+	Status                string    `json:"status"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+// ToolListResponse represents a list of tools
+type ToolListResponse struct {
+	Tools []ToolResponse `json:"tools"`
+}
@@ -1,15 +1,94 @@
 package dto
 
+import "time"
+
 // UserProfileResponse represents a user profile response
 type UserProfileResponse struct {
-	UserID  string `json:"userId"`
-	Email   string `json:"email"`
-	Message string `json:"message,omitempty"`
+	UserID        string                          `json:"userId"`
+	Email         string                          `json:"email"`
+	EmailVerified bool                            `json:"emailVerified"`
+	Roles         []string                        `json:"roles"`
+	DisplayName   string                          `json:"displayName"`
+	Phone         string                          `json:"phone"`
+	Address       AddressResponse                 `json:"address"`
+	AvatarURL     string                          `json:"avatarUrl"`
+	Bio           string                          `json:"bio"`
+	Notifications NotificationPreferencesResponse `json:"notifications"`
+	CreatedAt     time.Time                       `json:"createdAt"`
+	UpdatedAt     time.Time                       `json:"updatedAt"`
+}
+
+// AddressResponse represents a postal address in API responses
+type AddressResponse struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+// NotificationPreferencesResponse represents notification preferences in API responses
+type NotificationPreferencesResponse struct {
+	RentalUpdates bool `json:"rentalUpdates"`
+	Reminders     bool `json:"reminders"`
+	SMS           bool `json:"sms"`
+	Newsletter    bool `json:"newsletter"`
+}
+
+// UpdateProfileRequest represents a partial update of the user's profile.
+// Omitted fields are left unchanged; an empty string clears a field.
+type UpdateProfileRequest struct {
+	DisplayName   *string                         `json:"displayName"`
+	Phone         *string                         `json:"phone"`
+	Address       *AddressRequest                 `json:"address"`
+	AvatarURL     *string                         `json:"avatarUrl"`
+	Bio           *string                         `json:"bio"`
+	Notifications *NotificationPreferencesRequest `json:"notifications"`
 }
 
-// HealthCheckResponse represents a health check response
-type HealthCheckResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
+// AddressRequest represents a partial update of a postal address
+type AddressRequest struct {
+	Line1      *string `json:"line1"`
+	Line2      *string `json:"line2"`
+	City       *string `json:"city"`
+	Region     *string `json:"region"`
+	PostalCode *string `json:"postalCode"`
+	Country    *string `json:"country"`
 }
 
+// NotificationPreferencesRequest represents a partial update of notification preferences
+type NotificationPreferencesRequest struct {
+	RentalUpdates *bool `json:"rentalUpdates"`
+	Reminders     *bool `json:"reminders"`
+	SMS           *bool `json:"sms"`
+	Newsletter    *bool `json:"newsletter"`
+}
+
+// DataExportResponse bundles everything the club stores about a member
+type DataExportResponse struct {
+	ExportedAt     time.Time              `json:"exportedAt"`
+	Profile        UserProfileResponse    `json:"profile"`
+	Status         string                 `json:"status"`
+	StatusReason   string                 `json:"statusReason,omitempty"`
+	SuspendedUntil *time.Time             `json:"suspendedUntil,omitempty"`
+	Account        *AccountExportResponse `json:"account,omitempty"`
+	Rentals        []RentalResponse       `json:"rentals"`
+	Tools          []ToolResponse         `json:"tools"`
+	LentRentals    []RentalResponse       `json:"lentRentals"`
+}
+
+// AccountStatusExportResponse is the status part of a data export archive
+type AccountStatusExportResponse struct {
+	Status         string     `json:"status"`
+	StatusReason   string     `json:"statusReason,omitempty"`
+	SuspendedUntil *time.Time `json:"suspendedUntil,omitempty"`
+}
+
+// AccountExportResponse describes a member's password login. The password
+// hash is deliberately left out.
+type AccountExportResponse struct {
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	authApp "github.com/yourusername/toolrentalclub/application/auth"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// AccountHandler handles password accounts of the self-hosted auth provider
+type AccountHandler struct {
+	accountUseCase *authApp.AccountUseCase
+	keys           auth.KeyPublisher
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(accountUseCase *authApp.AccountUseCase, keys auth.KeyPublisher) *AccountHandler {
+	return &AccountHandler{
+		accountUseCase: accountUseCase,
+		keys:           keys,
+	}
+}
+
+// Register handles account registration requests
+func (h *AccountHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req dto.CredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	user, token, err := h.accountUseCase.Register(r.Context(), req.Email, req.Password)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusCreated, toAccessTokenResponse(user.ID, token))
+}
+
+// Login handles password login requests
+func (h *AccountHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req dto.CredentialsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	token, err := h.accountUseCase.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toAccessTokenResponse("", token))
+}
+
+// RequestPasswordReset handles requests for a password reset email. The
+// response is the same whether or not the email belongs to an account.
+func (h *AccountHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req dto.PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	if err := h.accountUseCase.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusAccepted, dto.MessageResponse{
+		Message: "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ResetPassword handles setting a new password with a reset token
+func (h *AccountHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.PasswordResetConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	if err := h.accountUseCase.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, dto.MessageResponse{Message: "Password has been reset"})
+}
+
+// JWKS serves the public keys that verify issued tokens as a JWK Set
+func (h *AccountHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	keys := h.keys.PublicKeys()
+
+	resp := dto.JSONWebKeySet{Keys: make([]dto.JSONWebKey, 0, len(keys))}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, dto.JSONWebKey{
+			KeyType:   k.KeyType,
+			KeyID:     k.KeyID,
+			Algorithm: k.Algorithm,
+			Use:       k.Use,
+			Curve:     k.Curve,
+			Modulus:   k.Modulus,
+			Exponent:  k.Exponent,
+			X:         k.X,
+		})
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+func toAccessTokenResponse(userID string, token *auth.IssuedToken) dto.AccessTokenResponse {
+	return dto.AccessTokenResponse{
+		UserID:      userID,
+		AccessToken: token.Value,
+		TokenType:   "Bearer",
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
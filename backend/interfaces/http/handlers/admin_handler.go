@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	userApp "github.com/yourusername/toolrentalclub/application/user"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// AdminHandler handles club administration HTTP requests
+type AdminHandler struct {
+	userUseCase *userApp.UseCase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(userUseCase *userApp.UseCase) *AdminHandler {
+	return &AdminHandler{
+		userUseCase: userUseCase,
+	}
+}
+
+// ListUsers handles requests to search and page through the club's users
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query, err := parseUserListQuery(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	page, err := h.userUseCase.ListUsers(r.Context(), query)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	resp := dto.UserListResponse{
+		Users:      make([]dto.UserSummaryResponse, 0, len(page.Users)),
+		NextCursor: page.NextCursor,
+	}
+	for _, u := range page.Users {
+		resp.Users = append(resp.Users, toUserSummaryResponse(u))
+	}
+	if page.NextCursor != "" {
+		next := *r.URL
+		params := next.Query()
+		params.Set("cursor", page.NextCursor)
+		next.RawQuery = params.Encode()
+		resp.Next = next.RequestURI()
+	}
+
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+// GetUserRoles handles requests to view a user's roles
+func (h *AdminHandler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	u, err := h.userUseCase.LookupUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserRolesResponse(u))
+}
+
+// GrantRole handles requests to give a user a role
+func (h *AdminHandler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	var req dto.RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	u, err := h.userUseCase.GrantRole(r.Context(), mux.Vars(r)["id"], user.Role(req.Role))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserRolesResponse(u))
+}
+
+// RevokeRole handles requests to take a role away from a user
+func (h *AdminHandler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	u, err := h.userUseCase.RevokeRole(r.Context(), vars["id"], user.Role(vars["role"]))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserRolesResponse(u))
+}
+
+// SuspendUser handles requests to suspend an account
+func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	var until time.Time
+	if req.Until != nil {
+		until = *req.Until
+	}
+
+	u, err := h.userUseCase.SuspendUser(r.Context(), mux.Vars(r)["id"], req.Reason, until)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserSummaryResponse(u))
+}
+
+// ReinstateUser handles requests to approve a pending account or lift a
+// suspension or closure
+func (h *AdminHandler) ReinstateUser(w http.ResponseWriter, r *http.Request) {
+	u, err := h.userUseCase.ReinstateUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserSummaryResponse(u))
+}
+
+// CloseUser handles requests to close an account
+func (h *AdminHandler) CloseUser(w http.ResponseWriter, r *http.Request) {
+	var req dto.StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	u, err := h.userUseCase.CloseUser(r.Context(), mux.Vars(r)["id"], req.Reason)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserSummaryResponse(u))
+}
+
+// parseUserListQuery reads the filters, sort and page of a user listing from
+// the query string. sort is createdAt or email, prefixed with "-" for
+// descending order.
+func parseUserListQuery(r *http.Request) (user.ListQuery, error) {
+	params := r.URL.Query()
+	query := user.ListQuery{
+		EmailPrefix: params.Get("email"),
+		Role:        user.Role(params.Get("role")),
+		Status:      user.Status(params.Get("status")),
+		Cursor:      params.Get("cursor"),
+	}
+
+	sort := params.Get("sort")
+	if strings.HasPrefix(sort, "-") {
+		query.Descending = true
+		sort = sort[1:]
+	}
+	query.Sort = user.SortField(sort)
+
+	for _, p := range []struct {
+		name string
+		dest *time.Time
+	}{
+		{"createdAfter", &query.CreatedAfter},
+		{"createdBefore", &query.CreatedBefore},
+	} {
+		if value := params.Get(p.name); value != "" {
+			parsed, err := parseTimeParam(value)
+			if err != nil {
+				return query, errInvalidRequest.WithField(p.name, err.Error())
+			}
+			*p.dest = parsed
+		}
+	}
+
+	if value := params.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return query, errInvalidRequest.WithField("limit", "must be a positive integer")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+func toUserSummaryResponse(u *user.User) dto.UserSummaryResponse {
+	resp := dto.UserSummaryResponse{
+		UserID:       u.ID,
+		Email:        u.Email,
+		DisplayName:  u.Profile.DisplayName,
+		Roles:        roleNames(u.Roles),
+		Status:       string(u.Status),
+		StatusReason: u.StatusReason,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+	}
+	if !u.SuspendedUntil.IsZero() {
+		until := u.SuspendedUntil
+		resp.SuspendedUntil = &until
+	}
+	return resp
+}
+
+func toUserRolesResponse(u *user.User) dto.UserRolesResponse {
+	return dto.UserRolesResponse{
+		UserID:      u.ID,
+		Email:       u.Email,
+		Roles:       roleNames(u.Roles),
+		Permissions: permissionNames(u.Roles),
+	}
+}
+
+// roleNames lists the roles including the implicit member role
+func roleNames(roles []user.Role) []string {
+	merged := user.MergeRoles([]user.Role{user.RoleMember}, roles)
+	names := make([]string, len(merged))
+	for i, r := range merged {
+		names[i] = string(r)
+	}
+	return names
+}
+
+func permissionNames(roles []user.Role) []string {
+	permissions := user.PermissionsFor(roles)
+	names := make([]string, len(permissions))
+	for i, p := range permissions {
+		names[i] = string(p)
+	}
+	return names
+}
@@ -4,17 +4,18 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/yourusername/toolrentalclub/application/auth"
+	authApp "github.com/yourusername/toolrentalclub/application/auth"
 	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
 )
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	authUseCase *auth.UseCase
+	authUseCase *authApp.UseCase
 }
 
 // NewAuthHandler creates a new authentication handler
-func NewAuthHandler(authUseCase *auth.UseCase) *AuthHandler {
+func NewAuthHandler(authUseCase *authApp.UseCase) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
 	}
@@ -24,29 +25,29 @@ func NewAuthHandler(authUseCase *auth.UseCase) *AuthHandler {
 func (h *AuthHandler) VerifyToken(w http.ResponseWriter, r *http.Request) {
 	var req dto.VerifyTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		response.Error(w, r, errInvalidPayload)
 		return
 	}
 
 	if req.Token == "" {
-		respondWithError(w, http.StatusBadRequest, "Token is required")
+		response.Error(w, r, errInvalidRequest.WithField("token", "is required"))
 		return
 	}
 
 	// Verify token and get or create user
-	token, user, err := h.authUseCase.VerifyTokenAndGetUser(r.Context(), req.Token)
+	_, user, err := h.authUseCase.VerifyTokenAndGetUser(r.Context(), req.Token)
 	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Invalid token")
+		response.Error(w, r, err)
 		return
 	}
 
-	response := dto.VerifyTokenResponse{
+	resp := dto.VerifyTokenResponse{
 		Success: true,
 		Message: "Token verified successfully",
 		UserID:  user.ID,
-		Email:   token.Email,
+		Email:   user.Email,
 	}
 
-	respondWithJSON(w, http.StatusOK, response)
+	response.JSON(w, r, http.StatusOK, resp)
 }
 
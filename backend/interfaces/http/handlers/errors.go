@@ -0,0 +1,12 @@
+package handlers
+
+import "github.com/yourusername/toolrentalclub/domain/errs"
+
+var (
+	// errInvalidPayload is returned when a request body is not valid JSON
+	errInvalidPayload = errs.Validation("invalid_payload", "invalid request payload")
+
+	// errInvalidRequest is returned with field details when a request misses
+	// or mangles a required field
+	errInvalidRequest = errs.Validation("invalid_request", "invalid request")
+)
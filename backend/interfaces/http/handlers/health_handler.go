@@ -4,22 +4,80 @@ import (
 	"net/http"
 
 	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/health"
 )
 
 // HealthHandler handles health check requests
-type HealthHandler struct{}
+type HealthHandler struct {
+	checks *health.Registry
+}
 
-// NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+// NewHealthHandler creates a new health handler that reports readiness from
+// the checks contributed by the server and its dependencies
+func NewHealthHandler(checks *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+	}
 }
 
-// HealthCheck handles health check requests
+// HealthCheck handles health check requests. It reports readiness, like Ready.
 func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	response := dto.HealthCheckResponse{
-		Status:  "ok",
+	h.Ready(w, r)
+}
+
+// Live handles liveness probes; it succeeds as long as the process can serve requests
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, r, http.StatusOK, dto.HealthCheckResponse{
+		Status:  string(health.StatusUp),
 		Message: "Tool Rental Club API is running",
+	})
+}
+
+// Ready handles readiness probes, returning 503 while a critical dependency is down
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.checks.Run(r.Context())
+
+	status := http.StatusOK
+	message := "Tool Rental Club API is ready"
+	switch report.Status {
+	case health.StatusDegraded:
+		message = "Tool Rental Club API is ready; some optional dependencies are failing"
+	case health.StatusDown:
+		status = http.StatusServiceUnavailable
+		message = "Tool Rental Club API is not ready"
 	}
-	respondWithJSON(w, http.StatusOK, response)
+
+	response.JSON(w, r, status, dto.HealthCheckResponse{
+		Status:     string(report.Status),
+		Message:    message,
+		Components: toComponentHealthResponses(report.Components),
+	})
 }
 
+// checkFailed stands in for the error of a failed check. The readiness
+// endpoint is public and raw errors can reveal hosts, paths or credentials
+// in connection strings, so they only go to the server log.
+const checkFailed = "check failed"
+
+func toComponentHealthResponses(components []health.ComponentStatus) []dto.ComponentHealthResponse {
+	responses := make([]dto.ComponentHealthResponse, 0, len(components))
+	for _, c := range components {
+		resp := dto.ComponentHealthResponse{
+			Name:      c.Name,
+			Status:    string(c.Status),
+			Critical:  c.Critical,
+			LatencyMs: float64(c.Latency.Microseconds()) / 1000,
+			CheckedAt: c.CheckedAt,
+		}
+		if c.LastError != "" {
+			resp.LastError = checkFailed
+		}
+		if !c.LastErrorAt.IsZero() {
+			lastErrorAt := c.LastErrorAt
+			resp.LastErrorAt = &lastErrorAt
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/pkg/health"
+)
+
+func TestHealthHandlerReady(t *testing.T) {
+	const secret = "dial tcp db.internal:5432: password authentication failed for user toolclub"
+	fail := func(context.Context) error { return errors.New(secret) }
+	pass := func(context.Context) error { return nil }
+
+	tests := []struct {
+		name        string
+		storage     health.Check
+		mail        health.Check
+		status      int
+		wantStatus  string
+		wantFailing []string
+	}{
+		{"all up", pass, pass, http.StatusOK, "up", nil},
+		{"non-critical down", pass, fail, http.StatusOK, "degraded", []string{"mail"}},
+		{"critical down", fail, pass, http.StatusServiceUnavailable, "down", []string{"storage"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := health.NewRegistry(time.Second)
+			checks.Register("storage", true, tt.storage)
+			checks.Register("mail", false, tt.mail)
+			handler := NewHealthHandler(checks)
+
+			rec := httptest.NewRecorder()
+			handler.Ready(rec, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if strings.Contains(rec.Body.String(), "db.internal") {
+				t.Errorf("body reveals the raw check error: %s", rec.Body)
+			}
+
+			var body dto.HealthCheckResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decoding %s: %v", rec.Body, err)
+			}
+			if body.Status != tt.wantStatus {
+				t.Errorf("status field = %q, want %q", body.Status, tt.wantStatus)
+			}
+
+			var failing []string
+			for _, c := range body.Components {
+				if c.Status == "up" {
+					if c.LastError != "" || c.LastErrorAt != nil {
+						t.Errorf("passing component %s reports an error", c.Name)
+					}
+					continue
+				}
+				failing = append(failing, c.Name)
+				if c.LastError != checkFailed || c.LastErrorAt == nil {
+					t.Errorf("failing component %s = %+v, want the generic error and its time", c.Name, c)
+				}
+			}
+			if strings.Join(failing, ",") != strings.Join(tt.wantFailing, ",") {
+				t.Errorf("failing components = %v, want %v", failing, tt.wantFailing)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	rentalApp "github.com/yourusername/toolrentalclub/application/rental"
+	"github.com/yourusername/toolrentalclub/domain/rental"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// RentalHandler handles reservation HTTP requests
+type RentalHandler struct {
+	rentalUseCase *rentalApp.UseCase
+}
+
+// NewRentalHandler creates a new rental handler
+func NewRentalHandler(rentalUseCase *rentalApp.UseCase) *RentalHandler {
+	return &RentalHandler{
+		rentalUseCase: rentalUseCase,
+	}
+}
+
+// CreateRental handles requests to reserve a tool for the authenticated user
+func (h *RentalHandler) CreateRental(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateRentalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	if req.ToolID == "" {
+		response.Error(w, r, errInvalidRequest.WithField("toolId", "is required"))
+		return
+	}
+
+	rent, err := h.rentalUseCase.RequestRental(r.Context(), req.ToolID, req.Start, req.End)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusCreated, toRentalResponse(rent))
+}
+
+// GetRental handles requests to view a rental
+func (h *RentalHandler) GetRental(w http.ResponseWriter, r *http.Request) {
+	rent, err := h.rentalUseCase.GetRental(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toRentalResponse(rent))
+}
+
+// ConfirmRental handles requests to confirm a reservation
+func (h *RentalHandler) ConfirmRental(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rentalUseCase.ConfirmRental)
+}
+
+// PickUpRental handles requests to mark a reserved tool as picked up
+func (h *RentalHandler) PickUpRental(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rentalUseCase.PickUpRental)
+}
+
+// ReturnRental handles requests to mark a rented tool as returned
+func (h *RentalHandler) ReturnRental(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rentalUseCase.ReturnRental)
+}
+
+// CancelRental handles requests to cancel a reservation
+func (h *RentalHandler) CancelRental(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, h.rentalUseCase.CancelRental)
+}
+
+// transition runs a state-transition use case for the rental in the URL
+func (h *RentalHandler) transition(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, id string) (*rental.Rental, error),
+) {
+	rent, err := apply(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toRentalResponse(rent))
+}
+
+func toRentalResponse(rent *rental.Rental) dto.RentalResponse {
+	return dto.RentalResponse{
+		ID:        rent.ID,
+		ToolID:    rent.ToolID,
+		RenterID:  rent.RenterID,
+		Start:     rent.Start,
+		End:       rent.End,
+		Status:    string(rent.Status),
+		CreatedAt: rent.CreatedAt,
+		UpdatedAt: rent.UpdatedAt,
+	}
+}
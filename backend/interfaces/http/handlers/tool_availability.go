@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	toolApp "github.com/yourusername/toolrentalclub/application/tool"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/ical"
+)
+
+const (
+	// defaultAvailabilityWindow is used when the request does not specify "to"
+	defaultAvailabilityWindow = 30 * 24 * time.Hour
+
+	// maxAvailabilityWindow bounds the range a single request may ask for
+	maxAvailabilityWindow = 366 * 24 * time.Hour
+
+	// calendarContentType is the media type of the iCalendar feed, without
+	// the charset parameter of ical.ContentType
+	calendarContentType = "text/calendar"
+)
+
+// GetAvailability handles requests for a tool's free/busy calendar.
+// It responds with JSON by default and with iCalendar when the client's
+// Accept header prefers text/calendar or it passes format=ics, so members
+// can subscribe to it from calendar apps that send no Accept header.
+func (h *ToolHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	contentType := calendarContentType
+	if r.URL.Query().Get("format") != "ics" {
+		contentType = response.Negotiate(r, response.ContentTypeJSON, calendarContentType)
+	}
+	if contentType == "" {
+		response.NotAcceptable(w, r, response.ContentTypeJSON, calendarContentType)
+		return
+	}
+
+	from, to, err := parseAvailabilityWindow(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	intervals, err := h.toolUseCase.GetAvailability(r.Context(), id, from, to)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if contentType == calendarContentType {
+		respondWithCalendar(w, r, id, intervals)
+		return
+	}
+
+	resp := dto.AvailabilityResponse{
+		ToolID:    id,
+		From:      from,
+		To:        to,
+		Intervals: make([]dto.AvailabilityInterval, 0, len(intervals)),
+	}
+	for _, interval := range intervals {
+		resp.Intervals = append(resp.Intervals, toAvailabilityInterval(interval))
+	}
+
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+// ListBlocks handles requests to list a tool's maintenance and blackout blocks
+func (h *ToolHandler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	blocks, err := h.toolUseCase.ListBlocks(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	resp := dto.BlockListResponse{Blocks: make([]dto.BlockResponse, 0, len(blocks))}
+	for _, b := range blocks {
+		resp.Blocks = append(resp.Blocks, toBlockResponse(b))
+	}
+
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+// CreateBlock handles requests to block a tool for maintenance or owner blackout dates
+func (h *ToolHandler) CreateBlock(w http.ResponseWriter, r *http.Request) {
+	var req dto.BlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	input := toolApp.BlockInput{
+		Kind:   tool.BlockKind(req.Kind),
+		Start:  req.Start,
+		End:    req.End,
+		Reason: req.Reason,
+	}
+
+	b, err := h.toolUseCase.AddBlock(r.Context(), mux.Vars(r)["id"], input)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusCreated, toBlockResponse(b))
+}
+
+// DeleteBlock handles requests to remove a block from a tool
+func (h *ToolHandler) DeleteBlock(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.toolUseCase.RemoveBlock(r.Context(), vars["id"], vars["blockId"]); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAvailabilityWindow reads the from/to query parameters, defaulting to
+// the next 30 days starting today (UTC)
+func parseAvailabilityWindow(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if value := query.Get("from"); value != "" {
+		parsed, err := parseTimeParam(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidRequest.WithField("from", err.Error())
+		}
+		from = parsed
+	}
+
+	to := from.Add(defaultAvailabilityWindow)
+	if value := query.Get("to"); value != "" {
+		parsed, err := parseTimeParam(value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidRequest.WithField("to", err.Error())
+		}
+		to = parsed
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, errInvalidRequest.WithField("to", "must be after from")
+	}
+	if to.Sub(from) > maxAvailabilityWindow {
+		return time.Time{}, time.Time{}, errInvalidRequest.WithField("to", "must be at most 366 days after from")
+	}
+
+	return from, to, nil
+}
+
+// parseTimeParam accepts an RFC 3339 timestamp or a plain date (midnight UTC)
+func parseTimeParam(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.New("must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	return t, nil
+}
+
+// respondWithCalendar writes the busy intervals of a tool as an iCalendar feed
+func respondWithCalendar(w http.ResponseWriter, r *http.Request, toolID string, intervals []tool.Interval) {
+	cal := ical.Calendar{
+		ProdID: "-//Tool Rental Club//Availability//EN",
+		Name:   "Tool " + toolID + " availability",
+		Stamp:  time.Now(),
+	}
+
+	for _, interval := range intervals {
+		if !interval.Busy {
+			continue
+		}
+		reasons := make([]string, 0, len(interval.Reasons))
+		for _, reason := range interval.Reasons {
+			reasons = append(reasons, string(reason))
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("%s-%d@toolrentalclub", toolID, interval.Start.Unix()),
+			Start:       interval.Start,
+			End:         interval.End,
+			Summary:     "Unavailable",
+			Description: strings.Join(reasons, ", "),
+		})
+	}
+
+	var body bytes.Buffer
+	if err := cal.Encode(&body); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.Content(w, r, http.StatusOK, ical.ContentType, body.Bytes())
+}
+
+func toAvailabilityInterval(interval tool.Interval) dto.AvailabilityInterval {
+	status := "free"
+	if interval.Busy {
+		status = "busy"
+	}
+
+	var reasons []string
+	for _, reason := range interval.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	return dto.AvailabilityInterval{
+		Start:   interval.Start,
+		End:     interval.End,
+		Status:  status,
+		Reasons: reasons,
+	}
+}
+
+func toBlockResponse(b *tool.Block) dto.BlockResponse {
+	return dto.BlockResponse{
+		ID:        b.ID,
+		ToolID:    b.ToolID,
+		Kind:      string(b.Kind),
+		Start:     b.Start,
+		End:       b.End,
+		Reason:    b.Reason,
+		CreatedAt: b.CreatedAt,
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	toolApp "github.com/yourusername/toolrentalclub/application/tool"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/infrastructure/repository/memory"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+)
+
+// newAvailabilityHandler returns a handler for a drill that is in
+// maintenance from 12 to 14 January 2030
+func newAvailabilityHandler(t *testing.T) *ToolHandler {
+	t.Helper()
+
+	ctx := context.Background()
+	tools := memory.NewToolRepository()
+	blocks := memory.NewToolBlockRepository()
+	if err := tools.Create(ctx, tool.NewTool("drill", "owner", "Drill")); err != nil {
+		t.Fatalf("Create(tool) = %v", err)
+	}
+	block := tool.NewBlock("b1", "drill", tool.BlockMaintenance,
+		time.Date(2030, time.January, 12, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, time.January, 14, 0, 0, 0, 0, time.UTC), "new battery")
+	if err := blocks.Create(ctx, block); err != nil {
+		t.Fatalf("Create(block) = %v", err)
+	}
+
+	return NewToolHandler(toolApp.NewUseCase(tools, blocks, memory.NewRentalRepository(blocks)))
+}
+
+func TestToolHandlerGetAvailability(t *testing.T) {
+	handler := newAvailabilityHandler(t)
+
+	tests := []struct {
+		name        string
+		query       string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"no Accept header", "", "", http.StatusOK, "application/json"},
+		{"any media type", "", "*/*", http.StatusOK, "application/json"},
+		{"JSON", "", "application/json", http.StatusOK, "application/json"},
+		{"calendar", "", "text/calendar", http.StatusOK, "text/calendar; charset=utf-8"},
+		{"calendar preferred", "", "application/json;q=0.5, text/calendar", http.StatusOK, "text/calendar; charset=utf-8"},
+		{"JSON preferred", "", "text/calendar;q=0.5, application/*", http.StatusOK, "application/json"},
+		{"calendar by query", "&format=ics", "", http.StatusOK, "text/calendar; charset=utf-8"},
+		{"neither", "", "text/html", http.StatusNotAcceptable, "application/problem+json"},
+		{"calendar refused", "", "text/calendar;q=0", http.StatusNotAcceptable, "application/problem+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/tools/drill/availability?from=2030-01-10&to=2030-01-20"+tt.query, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			r = mux.SetURLVars(r, map[string]string{"id": "drill"})
+			w := httptest.NewRecorder()
+
+			handler.GetAvailability(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+		})
+	}
+}
+
+func TestToolHandlerGetAvailabilityBodies(t *testing.T) {
+	handler := newAvailabilityHandler(t)
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/tools/drill/availability?from=2030-01-10&to=2030-01-20", nil)
+		r.Header.Set("Accept", accept)
+		r = mux.SetURLVars(r, map[string]string{"id": "drill"})
+		w := httptest.NewRecorder()
+		handler.GetAvailability(w, r)
+		return w
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		var resp dto.AvailabilityResponse
+		if err := json.NewDecoder(get("application/json").Body).Decode(&resp); err != nil {
+			t.Fatalf("decoding the response: %v", err)
+		}
+
+		var statuses []string
+		for _, interval := range resp.Intervals {
+			statuses = append(statuses, interval.Status)
+		}
+		if got := strings.Join(statuses, ","); got != "free,busy,free" {
+			t.Errorf("intervals = %s, want free,busy,free", got)
+		}
+	})
+
+	t.Run("calendar", func(t *testing.T) {
+		body := get("text/calendar").Body.String()
+
+		if n := strings.Count(body, "BEGIN:VEVENT\r\n"); n != 1 {
+			t.Errorf("calendar holds %d events, want the one busy interval:\n%s", n, body)
+		}
+		for _, want := range []string{"DTSTART:20300112T000000Z\r\n", "DTEND:20300114T000000Z\r\n", "DESCRIPTION:maintenance\r\n"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("calendar lacks %q:\n%s", want, body)
+			}
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	toolApp "github.com/yourusername/toolrentalclub/application/tool"
+	"github.com/yourusername/toolrentalclub/domain/tool"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// ToolHandler handles tool catalog HTTP requests
+type ToolHandler struct {
+	toolUseCase *toolApp.UseCase
+}
+
+// NewToolHandler creates a new tool handler
+func NewToolHandler(toolUseCase *toolApp.UseCase) *ToolHandler {
+	return &ToolHandler{
+		toolUseCase: toolUseCase,
+	}
+}
+
+// ListTools handles requests to list the tool catalog
+func (h *ToolHandler) ListTools(w http.ResponseWriter, r *http.Request) {
+	tools, err := h.toolUseCase.ListTools(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	resp := dto.ToolListResponse{Tools: make([]dto.ToolResponse, 0, len(tools))}
+	for _, t := range tools {
+		resp.Tools = append(resp.Tools, toToolResponse(t))
+	}
+
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+// GetTool handles requests to get a single tool
+func (h *ToolHandler) GetTool(w http.ResponseWriter, r *http.Request) {
+	t, err := h.toolUseCase.GetTool(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toToolResponse(t))
+}
+
+// CreateTool handles requests to add a tool owned by the authenticated user
+func (h *ToolHandler) CreateTool(w http.ResponseWriter, r *http.Request) {
+	var req dto.ToolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	t, err := h.toolUseCase.CreateTool(r.Context(), toToolInput(req))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusCreated, toToolResponse(t))
+}
+
+// UpdateTool handles requests to update a tool owned by the authenticated user
+func (h *ToolHandler) UpdateTool(w http.ResponseWriter, r *http.Request) {
+	var req dto.ToolRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	t, err := h.toolUseCase.UpdateTool(r.Context(), mux.Vars(r)["id"], toToolInput(req))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toToolResponse(t))
+}
+
+// DeleteTool handles requests to remove a tool owned by the authenticated user
+func (h *ToolHandler) DeleteTool(w http.ResponseWriter, r *http.Request) {
+	if err := h.toolUseCase.DeleteTool(r.Context(), mux.Vars(r)["id"]); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toToolInput(req dto.ToolRequest) toolApp.ToolInput {
+	return toolApp.ToolInput{
+		Name:                  req.Name,
+		Description:           req.Description,
+		Category:              tool.Category(req.Category),
+		Condition:             tool.Condition(req.Condition),
+		ReplacementValueCents: req.ReplacementValueCents,
+		DailyRateCents:        req.DailyRateCents,
+		Status:                tool.Status(req.Status),
+	}
+}
+
+func toToolResponse(t *tool.Tool) dto.ToolResponse {
+	return dto.ToolResponse{
+		ID:                    t.ID,
+		Name:                  t.Name,
+		Description:           t.Description,
+		Category:              string(t.Category),
+		Condition:             string(t.Condition),
+		ReplacementValueCents: t.ReplacementValueCents,
+		DailyRateCents:        t.DailyRateCents,
+		OwnerID:               t.OwnerID,
+		Status:                string(t.Status),
+		CreatedAt:             t.CreatedAt,
+		UpdatedAt:             t.UpdatedAt,
+	}
+}
@@ -1,19 +1,27 @@
 package handlers
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"strconv"
 
-	"github.com/yourusername/toolrentalclub/application/user"
+	userApp "github.com/yourusername/toolrentalclub/application/user"
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/user"
 	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
 )
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userUseCase *user.UseCase
+	userUseCase *userApp.UseCase
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(userUseCase *user.UseCase) *UserHandler {
+func NewUserHandler(userUseCase *userApp.UseCase) *UserHandler {
 	return &UserHandler{
 		userUseCase: userUseCase,
 	}
@@ -21,26 +29,213 @@ func NewUserHandler(userUseCase *user.UseCase) *UserHandler {
 
 // GetProfile handles requests to get the authenticated user's profile
 func (h *UserHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
-	// Get user ID from context (set by auth middleware)
-	userID, ok := r.Context().Value("userID").(string)
-	if !ok || userID == "" {
-		respondWithError(w, http.StatusUnauthorized, "Unauthorized - authentication required")
+	// Get the authenticated user from context (set by auth middleware)
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		response.Error(w, r, auth.ErrUnauthenticated)
 		return
 	}
 
 	// Get user from repository
-	user, err := h.userUseCase.GetUserByID(r.Context(), userID)
+	u, err := h.userUseCase.GetCurrentUser(r.Context())
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, "User not found")
+		response.Error(w, r, err)
 		return
 	}
 
-	response := dto.UserProfileResponse{
-		UserID:  user.ID,
-		Email:   user.Email,
-		Message: "This is a protected route",
+	response.JSON(w, r, http.StatusOK, toUserProfileResponse(u, principal.Roles))
+}
+
+// UpdateProfile handles requests to partially update the authenticated user's profile
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFromContext(r.Context())
+	if !ok {
+		response.Error(w, r, auth.ErrUnauthenticated)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, r, errInvalidPayload)
+		return
+	}
+
+	u, err := h.userUseCase.UpdateProfile(r.Context(), toProfileInput(req))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, http.StatusOK, toUserProfileResponse(u, principal.Roles))
+}
+
+// DeleteProfile handles requests to delete the authenticated user's account
+func (h *UserHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	if err := h.userUseCase.DeleteAccount(r.Context()); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ExportData handles requests to download everything the club stores about
+// the authenticated user, as JSON or, with ?format=zip, as a zip archive
+func (h *UserHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	export, err := h.userUseCase.ExportData(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	resp := toDataExportResponse(export)
+	if r.URL.Query().Get("format") == "zip" {
+		respondWithArchive(w, r, resp)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="toolrentalclub-export.json"`)
+	response.JSON(w, r, http.StatusOK, resp)
+}
+
+// respondWithArchive writes an export as a zip archive with one JSON file
+// per kind of data
+func respondWithArchive(w http.ResponseWriter, r *http.Request, export dto.DataExportResponse) {
+	type archiveFile struct {
+		name    string
+		content interface{}
+	}
+	files := []archiveFile{
+		{"profile.json", export.Profile},
+		{"rentals.json", export.Rentals},
+		{"tools.json", export.Tools},
+		{"lent-rentals.json", export.LentRentals},
+		{"status.json", dto.AccountStatusExportResponse{
+			Status:         export.Status,
+			StatusReason:   export.StatusReason,
+			SuspendedUntil: export.SuspendedUntil,
+		}},
+	}
+	if export.Account != nil {
+		files = append(files, archiveFile{"account.json", export.Account})
 	}
 
-	respondWithJSON(w, http.StatusOK, response)
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, f := range files {
+		content, err := json.MarshalIndent(f.content, "", "  ")
+		if err != nil {
+			response.Error(w, r, err)
+			return
+		}
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			response.Error(w, r, err)
+			return
+		}
+		if _, err := file.Write(content); err != nil {
+			response.Error(w, r, err)
+			return
+		}
+	}
+	if err := archive.Close(); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="toolrentalclub-export.zip"`)
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to write response", "status", http.StatusOK, "error", err)
+	}
 }
 
+func toDataExportResponse(export *userApp.DataExport) dto.DataExportResponse {
+	resp := dto.DataExportResponse{
+		ExportedAt:   export.ExportedAt,
+		Profile:      toUserProfileResponse(export.User, export.User.Roles),
+		Status:       string(export.User.Status),
+		StatusReason: export.User.StatusReason,
+		Rentals:      make([]dto.RentalResponse, 0, len(export.Rentals)),
+		Tools:        make([]dto.ToolResponse, 0, len(export.Tools)),
+		LentRentals:  make([]dto.RentalResponse, 0, len(export.Lent)),
+	}
+	if until := export.User.SuspendedUntil; !until.IsZero() {
+		resp.SuspendedUntil = &until
+	}
+	if c := export.Credential; c != nil {
+		resp.Account = &dto.AccountExportResponse{Email: c.Email, CreatedAt: c.CreatedAt, UpdatedAt: c.UpdatedAt}
+	}
+	for _, rent := range export.Rentals {
+		resp.Rentals = append(resp.Rentals, toRentalResponse(rent))
+	}
+	for _, t := range export.Tools {
+		resp.Tools = append(resp.Tools, toToolResponse(t))
+	}
+	for _, rent := range export.Lent {
+		resp.LentRentals = append(resp.LentRentals, toRentalResponse(rent))
+	}
+	return resp
+}
+
+func toProfileInput(req dto.UpdateProfileRequest) userApp.ProfileInput {
+	input := userApp.ProfileInput{
+		DisplayName: req.DisplayName,
+		Phone:       req.Phone,
+		AvatarURL:   req.AvatarURL,
+		Bio:         req.Bio,
+	}
+	if a := req.Address; a != nil {
+		input.Address = &userApp.AddressInput{
+			Line1:      a.Line1,
+			Line2:      a.Line2,
+			City:       a.City,
+			Region:     a.Region,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+		}
+	}
+	if n := req.Notifications; n != nil {
+		input.Notifications = &userApp.NotificationInput{
+			RentalUpdates: n.RentalUpdates,
+			Reminders:     n.Reminders,
+			SMS:           n.SMS,
+			Newsletter:    n.Newsletter,
+		}
+	}
+	return input
+}
+
+// toUserProfileResponse describes a user's profile. Roles come from the
+// principal, since a provider may grant roles the stored user does not hold.
+func toUserProfileResponse(u *user.User, roles []user.Role) dto.UserProfileResponse {
+	p := u.Profile
+	return dto.UserProfileResponse{
+		UserID:        u.ID,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Roles:         roleNames(roles),
+		DisplayName:   p.DisplayName,
+		Phone:         p.Phone,
+		Address: dto.AddressResponse{
+			Line1:      p.Address.Line1,
+			Line2:      p.Address.Line2,
+			City:       p.Address.City,
+			Region:     p.Address.Region,
+			PostalCode: p.Address.PostalCode,
+			Country:    p.Address.Country,
+		},
+		AvatarURL: p.AvatarURL,
+		Bio:       p.Bio,
+		Notifications: dto.NotificationPreferencesResponse{
+			RentalUpdates: p.Notifications.RentalUpdates,
+			Reminders:     p.Notifications.Reminders,
+			SMS:           p.Notifications.SMS,
+			Newsletter:    p.Notifications.Newsletter,
+		},
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
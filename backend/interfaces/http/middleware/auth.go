@@ -1,55 +1,109 @@
 package middleware
 
 import (
-	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/yourusername/toolrentalclub/application/auth"
+	domainAuth "github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
 )
 
-// AuthMiddleware creates middleware that validates authentication tokens
-func AuthMiddleware(authUseCase *auth.UseCase) func(http.Handler) http.Handler {
+// AuthMiddleware creates middleware that validates authentication tokens and
+// counts verification outcomes
+func AuthMiddleware(authUseCase *auth.UseCase, m *metrics.Metrics) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get the token from the Authorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				respondWithError(w, http.StatusUnauthorized, "Authorization header required")
+			idToken, reason := bearerToken(r)
+			if reason != "" {
+				m.ObserveAuthVerification(false, reason)
+				response.Error(w, r, bearerErrors[reason])
 				return
 			}
 
-			// Extract the token (format: "Bearer <token>")
-			parts := strings.Split(authHeader, " ")
-			if len(parts) != 2 || parts[0] != "Bearer" {
-				respondWithError(w, http.StatusUnauthorized, "Invalid authorization header format")
+			// Verify the token and identify the user
+			principal, err := authUseCase.Authenticate(r.Context(), idToken)
+			if err != nil {
+				respondWithAuthError(w, r, err, m)
 				return
 			}
 
-			idToken := parts[1]
+			m.ObserveAuthVerification(true, "")
 
-			// Verify the token
-			token, err := authUseCase.VerifyToken(r.Context(), idToken)
-			if err != nil {
-				respondWithError(w, http.StatusUnauthorized, "Invalid or expired token")
+			// Add the principal to the context
+			ctx := domainAuth.WithPrincipal(r.Context(), principal)
+			ctx = withAuthenticatedUser(ctx, principal)
+
+			// Call the next handler with the updated context
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RevocationCheckMiddleware creates middleware that verifies the bearer token
+// again, asking the identity provider whether it has been revoked. It must
+// run after the auth middleware and is meant for sensitive routes only, as
+// it costs a round trip to the provider on every request.
+func RevocationCheckMiddleware(authUseCase *auth.UseCase, m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idToken, reason := bearerToken(r)
+			if reason != "" {
+				m.ObserveAuthVerification(false, reason)
+				response.Error(w, r, bearerErrors[reason])
 				return
 			}
 
-			// Add user info to context
-			ctx := context.WithValue(r.Context(), "userID", token.UserID)
-			ctx = context.WithValue(ctx, "email", token.Email)
+			if _, err := authUseCase.VerifyTokenAndCheckRevoked(r.Context(), idToken); err != nil {
+				respondWithAuthError(w, r, err, m)
+				return
+			}
 
-			// Call the next handler with the updated context
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r)
 		})
 	}
 }
 
-// respondWithError is a helper function to send error responses
-func respondWithError(w http.ResponseWriter, code int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	// Simple error response without importing the handlers package
-	w.Write([]byte(`{"error":"` + message + `"}`))
+// respondWithAuthError answers a request whose token could not be verified
+// or whose account may not be used. Only a rejected token or an inactive
+// account is the client's fault; when the provider or the user storage fails
+// the request is answered with 503 or 500 instead of 401.
+func respondWithAuthError(w http.ResponseWriter, r *http.Request, err error, m *metrics.Metrics) {
+	switch {
+	case errors.Is(err, domainAuth.ErrTokenRevoked):
+		m.ObserveAuthVerification(false, "revoked_token")
+	case errs.KindOf(err) == errs.KindUnauthenticated:
+		m.ObserveAuthVerification(false, "invalid_token")
+	case errs.KindOf(err) == errs.KindForbidden:
+		m.ObserveAuthVerification(false, "account_inactive")
+	case errs.KindOf(err) == errs.KindUnavailable:
+		m.ObserveAuthVerification(false, "provider_unavailable")
+	default:
+		m.ObserveAuthVerification(false, "error")
+	}
+	response.Error(w, r, err)
 }
 
+// bearerErrors are the errors for each reason bearerToken rejects a request for
+var bearerErrors = map[string]error{
+	"missing_header":   errs.Unauthenticated("authorization_required", "authorization header required"),
+	"malformed_header": errs.Unauthenticated("malformed_authorization", "authorization header must be \"Bearer <token>\""),
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>"
+// header, or returns the reason it could not
+func bearerToken(r *http.Request) (token, reason string) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", "missing_header"
+	}
+
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return "", "malformed_header"
+	}
+	return parts[1], ""
+}
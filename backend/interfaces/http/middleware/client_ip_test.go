@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		entries []string
+		wantErr bool
+	}{
+		{nil, false},
+		{[]string{"10.0.0.1", "192.168.0.0/16", "::1", "fd00::/8"}, false},
+		{[]string{"10.0.0.256"}, true},
+		{[]string{"10.0.0.0/33"}, true},
+		{[]string{"proxy.internal"}, true},
+	}
+	for _, tt := range tests {
+		if _, err := ParseTrustedProxies(tt.entries); (err != nil) != tt.wantErr {
+			t.Errorf("ParseTrustedProxies(%q) = %v, want error %t", tt.entries, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.1", "192.168.0.0/16", "::1"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		proxies      *TrustedProxies
+		remoteAddr   string
+		forwardedFor []string
+		want         string
+	}{
+		{"direct client", proxies, "203.0.113.7:41000", nil, "203.0.113.7"},
+		{"untrusted peer cannot spoof its address", proxies, "203.0.113.7:41000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"no trusted proxies configured", nil, "10.0.0.1:41000", []string{"198.51.100.1"}, "10.0.0.1"},
+		{"trusted proxy", proxies, "10.0.0.1:41000", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"trusted proxy without header", proxies, "10.0.0.1:41000", nil, "10.0.0.1"},
+		{"chain of trusted proxies", proxies, "10.0.0.1:41000", []string{"198.51.100.1, 192.168.4.2"}, "198.51.100.1"},
+		{"client-supplied hops left of the client are ignored", proxies, "10.0.0.1:41000", []string{"1.2.3.4, 198.51.100.1, 192.168.4.2"}, "198.51.100.1"},
+		{"header split over several lines", proxies, "10.0.0.1:41000", []string{"1.2.3.4", "198.51.100.1"}, "198.51.100.1"},
+		{"only trusted hops", proxies, "10.0.0.1:41000", []string{"192.168.4.2"}, "192.168.4.2"},
+		{"malformed hop stops the walk", proxies, "10.0.0.1:41000", []string{"198.51.100.1, unknown, 192.168.4.2"}, "192.168.4.2"},
+		{"IPv6 proxy", proxies, "[::1]:41000", []string{"2001:db8::7"}, "2001:db8::7"},
+		{"empty hops are skipped", proxies, "10.0.0.1:41000", []string{"198.51.100.1, , "}, "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			if got := tt.proxies.ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,25 +1,224 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
 )
 
-// CORSMiddleware adds CORS headers to responses
-func CORSMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "3600")
-
-		// Handle preflight requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+// CORSConfig describes which cross-origin requests browsers may make
+type CORSConfig struct {
+	// AllowedOrigins are origins such as https://club.example.com. An entry
+	// may use a wildcard for subdomains (https://*.example.com) or be "*" to
+	// allow every origin.
+	AllowedOrigins []string
+
+	AllowedMethods []string
+	AllowedHeaders []string
+
+	// ExposedHeaders are response headers that scripts may read
+	ExposedHeaders []string
+
+	// AllowCredentials lets browsers send cookies and HTTP authentication.
+	// It cannot be combined with the "*" origin.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// CORSPolicy is a validated CORSConfig
+type CORSPolicy struct {
+	anyOrigin bool
+	origins   []originPattern
+	methods   map[string]bool
+	headers   map[string]bool
+
+	allowMethods     string
+	allowHeaders     string
+	exposeHeaders    string
+	allowCredentials bool
+	maxAge           string
+}
+
+// originPattern matches an origin exactly or, with wildcard set, any
+// subdomain of host
+type originPattern struct {
+	scheme   string
+	host     string
+	port     string
+	wildcard bool
+}
+
+// NewCORSPolicy validates the configuration
+func NewCORSPolicy(cfg CORSConfig) (*CORSPolicy, error) {
+	p := &CORSPolicy{
+		methods:          make(map[string]bool),
+		headers:          make(map[string]bool),
+		allowCredentials: cfg.AllowCredentials,
+		maxAge:           strconv.Itoa(int(cfg.MaxAge.Seconds())),
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			p.anyOrigin = true
+			continue
 		}
+		pattern, err := parseOriginPattern(origin)
+		if err != nil {
+			return nil, err
+		}
+		p.origins = append(p.origins, pattern)
+	}
+	if p.anyOrigin && cfg.AllowCredentials {
+		return nil, fmt.Errorf("CORS credentials cannot be allowed for every origin; list the allowed origins instead of *")
+	}
+
+	methods := make([]string, 0, len(cfg.AllowedMethods))
+	for _, method := range cfg.AllowedMethods {
+		method = strings.ToUpper(method)
+		p.methods[method] = true
+		methods = append(methods, method)
+	}
+	p.allowMethods = strings.Join(methods, ", ")
+
+	for _, header := range cfg.AllowedHeaders {
+		p.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	p.allowHeaders = strings.Join(cfg.AllowedHeaders, ", ")
+	p.exposeHeaders = strings.Join(cfg.ExposedHeaders, ", ")
+
+	return p, nil
+}
+
+func parseOriginPattern(origin string) (originPattern, error) {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q (expected scheme://host[:port])", origin)
+	}
+
+	pattern := originPattern{
+		scheme: strings.ToLower(u.Scheme),
+		host:   strings.ToLower(u.Hostname()),
+		port:   u.Port(),
+	}
+	if strings.HasPrefix(pattern.host, "*.") {
+		pattern.wildcard = true
+		pattern.host = strings.TrimPrefix(pattern.host, "*")
+	}
+	if strings.Contains(pattern.host, "*") {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q (wildcards are only allowed as the first label)", origin)
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	return pattern, nil
 }
 
+// allowsOrigin reports whether origin may make cross-origin requests
+func (p *CORSPolicy) allowsOrigin(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	scheme, host, port := strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), u.Port()
+
+	for _, pattern := range p.origins {
+		if pattern.scheme != scheme || pattern.port != port {
+			continue
+		}
+		if pattern.wildcard {
+			// pattern.host is ".example.com"; require at least one more label
+			if strings.HasSuffix(host, pattern.host) && len(host) > len(pattern.host) {
+				return true
+			}
+			continue
+		}
+		if pattern.host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders reports whether every header in a comma-separated
+// Access-Control-Request-Headers value is allowed
+func (p *CORSPolicy) allowsHeaders(requested string) bool {
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !p.headers[http.CanonicalHeaderKey(header)] {
+			return false
+		}
+	}
+	return true
+}
+
+// CORSMiddleware applies the CORS policy. Requests from allowed origins get
+// their origin echoed back; preflight requests from other origins, or asking
+// for methods or headers outside the policy, are rejected with 403.
+func CORSMiddleware(p *CORSPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The response depends on the Origin header, so caches must key on it
+			w.Header().Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+				if origin == "" || !p.allowsOrigin(origin) {
+					response.Problem(w, r, http.StatusForbidden, "cors_origin_not_allowed", "origin not allowed")
+					return
+				}
+				if !p.methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+					response.Problem(w, r, http.StatusForbidden, "cors_method_not_allowed", "method not allowed by CORS policy")
+					return
+				}
+				if !p.allowsHeaders(r.Header.Get("Access-Control-Request-Headers")) {
+					response.Problem(w, r, http.StatusForbidden, "cors_header_not_allowed", "header not allowed by CORS policy")
+					return
+				}
+
+				p.setOriginHeaders(w, origin)
+				w.Header().Set("Access-Control-Allow-Methods", p.allowMethods)
+				if p.allowHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", p.allowHeaders)
+				}
+				w.Header().Set("Access-Control-Max-Age", p.maxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if origin != "" && p.allowsOrigin(origin) {
+				p.setOriginHeaders(w, origin)
+				if p.exposeHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", p.exposeHeaders)
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setOriginHeaders allows origin to read the response
+func (p *CORSPolicy) setOriginHeaders(w http.ResponseWriter, origin string) {
+	if p.anyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
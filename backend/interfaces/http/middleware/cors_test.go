@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSPolicyAllowsOrigin(t *testing.T) {
+	policy, err := NewCORSPolicy(CORSConfig{
+		AllowedOrigins: []string{"https://club.example.com", "https://*.tools.example.org", "http://localhost:3000"},
+	})
+	if err != nil {
+		t.Fatalf("NewCORSPolicy() = %v", err)
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://club.example.com", true},
+		{"HTTPS://Club.Example.com", true},
+		{"http://club.example.com", false},
+		{"https://club.example.com:8443", false},
+		{"https://evil.club.example.com", false},
+		{"https://club.example.com.evil.com", false},
+		{"https://a.tools.example.org", true},
+		{"https://a.b.tools.example.org", true},
+		{"https://tools.example.org", false},
+		{"https://eviltools.example.org", false},
+		{"http://localhost:3000", true},
+		{"http://localhost:3001", false},
+		{"http://localhost", false},
+		{"null", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			if got := policy.allowsOrigin(tt.origin); got != tt.want {
+				t.Errorf("allowsOrigin(%q) = %t, want %t", tt.origin, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCORSPolicyRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  CORSConfig
+	}{
+		{"missing scheme", CORSConfig{AllowedOrigins: []string{"club.example.com"}}},
+		{"path", CORSConfig{AllowedOrigins: []string{"https://club.example.com/app"}}},
+		{"inner wildcard", CORSConfig{AllowedOrigins: []string{"https://club.*.example.com"}}},
+		{"credentials for every origin", CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCORSPolicy(tt.cfg); err == nil {
+				t.Error("NewCORSPolicy() = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	policy, err := NewCORSPolicy(CORSConfig{
+		AllowedOrigins:   []string{"https://club.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+	})
+	if err != nil {
+		t.Fatalf("NewCORSPolicy() = %v", err)
+	}
+	handler := CORSMiddleware(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	tests := []struct {
+		name        string
+		method      string
+		header      http.Header
+		status      int
+		allowOrigin string
+	}{
+		{
+			name:        "simple request from allowed origin",
+			method:      http.MethodGet,
+			header:      http.Header{"Origin": {"https://club.example.com"}},
+			status:      http.StatusTeapot,
+			allowOrigin: "https://club.example.com",
+		},
+		{
+			name:   "simple request from other origin",
+			method: http.MethodGet,
+			header: http.Header{"Origin": {"https://evil.example.com"}},
+			status: http.StatusTeapot,
+		},
+		{
+			name:   "request without origin",
+			method: http.MethodGet,
+			status: http.StatusTeapot,
+		},
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                         {"https://club.example.com"},
+				"Access-Control-Request-Method":  {"POST"},
+				"Access-Control-Request-Headers": {"authorization, content-type"},
+			},
+			status:      http.StatusNoContent,
+			allowOrigin: "https://club.example.com",
+		},
+		{
+			name:   "preflight from other origin",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                        {"https://evil.example.com"},
+				"Access-Control-Request-Method": {"POST"},
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "preflight for other method",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                        {"https://club.example.com"},
+				"Access-Control-Request-Method": {"DELETE"},
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "preflight for other header",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                         {"https://club.example.com"},
+				"Access-Control-Request-Method":  {"GET"},
+				"Access-Control-Request-Headers": {"X-Dev-User-ID"},
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:        "options without preflight headers",
+			method:      http.MethodOptions,
+			header:      http.Header{"Origin": {"https://club.example.com"}},
+			status:      http.StatusTeapot,
+			allowOrigin: "https://club.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/tools", nil)
+			for name, values := range tt.header {
+				r.Header[name] = values
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowOrigin)
+			}
+			if credentials := w.Header().Get("Access-Control-Allow-Credentials"); (credentials == "true") != (tt.allowOrigin != "") {
+				t.Errorf("Access-Control-Allow-Credentials = %q with allowed origin %q", credentials, tt.allowOrigin)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/application/auth"
+	domainAuth "github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// Headers that select the identity of a request in dev-insecure mode
+const (
+	DevUserIDHeader = "X-Dev-User-ID"
+	DevEmailHeader  = "X-Dev-User-Email"
+	DevRolesHeader  = "X-Dev-User-Roles"
+)
+
+// DevAuthMiddleware creates middleware that trusts the identity in the
+// X-Dev-User-ID, X-Dev-User-Email and X-Dev-User-Roles (comma-separated)
+// headers, falling back to the given default identity. It performs no
+// authentication at all and must only be used for local development.
+func DevAuthMiddleware(authUseCase *auth.UseCase, defaultUserID, defaultEmail string, defaultRoles []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := domainAuth.NewToken("", defaultUserID, defaultEmail)
+			token.Roles = defaultRoles
+			if id := r.Header.Get(DevUserIDHeader); id != "" {
+				token = domainAuth.NewToken("", id, r.Header.Get(DevEmailHeader))
+				token.Roles = strings.Split(r.Header.Get(DevRolesHeader), ",")
+			}
+			token.Provider = "dev-insecure"
+			token.AuthTime = time.Now()
+
+			// Create the user on first use, as a real sign-in would
+			principal, err := authUseCase.PrincipalFor(r.Context(), token)
+			if err != nil {
+				response.Error(w, r, err)
+				return
+			}
+
+			ctx := domainAuth.WithPrincipal(r.Context(), principal)
+			ctx = withAuthenticatedUser(ctx, principal)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DenyAllMiddleware rejects every request. It guards protected routes when
+// authentication is disabled so that they fail closed instead of open.
+func DenyAllMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, r, domainAuth.ErrProviderUnavailable)
+	})
+}
@@ -1,26 +1,115 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"net/http"
+	"regexp"
 	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+
+	domainAuth "github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
 )
 
-// LoggingMiddleware logs HTTP requests
-func LoggingMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Call the next handler
-		next.ServeHTTP(w, r)
-
-		// Log the request
-		log.Printf(
-			"%s %s %s",
-			r.Method,
-			r.RequestURI,
-			time.Since(start),
-		)
-	})
+// RequestIDHeader carries the request ID from clients and proxies and back in responses
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits incoming request IDs to short, log-safe tokens
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// requestLog collects details that are only known deeper in the handler
+// chain, such as the authenticated user
+type requestLog struct {
+	userID string
+}
+
+type requestLogKey struct{}
+
+// LoggingMiddleware assigns each request an ID, puts a logger annotated with
+// it in the request context, and writes a structured access log entry once
+// the response is sent. An incoming X-Request-ID is kept if well formed.
+func LoggingMiddleware(logger *slog.Logger, proxies *TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID.MatchString(requestID) {
+				requestID = uuid.NewString()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			requestLogger := logger.With("request_id", requestID)
+			if span := trace.SpanContextFromContext(r.Context()); span.HasTraceID() {
+				requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+			}
+
+			entry := &requestLog{}
+			ctx := logging.WithRequestID(r.Context(), requestID)
+			ctx = logging.WithLogger(ctx, requestLogger)
+			ctx = context.WithValue(ctx, requestLogKey{}, entry)
+
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			level := slog.LevelInfo
+			if rw.status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rw.status),
+				slog.Int64("bytes", rw.bytes),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_addr", proxies.ClientIP(r)),
+				slog.String("user_agent", r.UserAgent()),
+			}
+			if entry.userID != "" {
+				attrs = append(attrs, slog.String("user_id", entry.userID))
+			}
+			requestLogger.LogAttrs(r.Context(), level, "request", attrs...)
+		})
+	}
+}
+
+// withAuthenticatedUser records the principal in the access log and returns a
+// context whose logger is annotated with the user ID
+func withAuthenticatedUser(ctx context.Context, principal *domainAuth.Principal) context.Context {
+	if entry, ok := ctx.Value(requestLogKey{}).(*requestLog); ok {
+		entry.userID = principal.UserID
+	}
+	return logging.WithLogger(ctx, logging.FromContext(ctx).With("user_id", principal.UserID))
+}
+
+// responseWriter records the status code and size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
 }
 
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
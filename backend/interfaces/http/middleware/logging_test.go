@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domainAuth "github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.1"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies() = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		requestID     string
+		keepRequestID bool
+		status        int
+		level         string
+	}{
+		{"no request ID", "", false, http.StatusOK, "INFO"},
+		{"well-formed request ID", "edge-7f3a:42", true, http.StatusCreated, "INFO"},
+		{"request ID with spaces", "forged entry", false, http.StatusOK, "INFO"},
+		{"request ID too long", strings.Repeat("a", 129), false, http.StatusOK, "INFO"},
+		{"server error", "", false, http.StatusInternalServerError, "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+			var handlerRequestID string
+			handler := LoggingMiddleware(logger, proxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerRequestID = logging.RequestIDFromContext(r.Context())
+				withAuthenticatedUser(r.Context(), &domainAuth.Principal{UserID: "u1"})
+				w.WriteHeader(tt.status)
+				w.Write([]byte("hello"))
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/api/tools/drill", nil)
+			r.RemoteAddr = "10.0.0.1:41000"
+			r.Header.Set("X-Forwarded-For", "198.51.100.1")
+			if tt.requestID != "" {
+				r.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			requestID := rec.Header().Get(RequestIDHeader)
+			if tt.keepRequestID && requestID != tt.requestID {
+				t.Errorf("request ID = %q, want the incoming %q", requestID, tt.requestID)
+			}
+			if !tt.keepRequestID && (requestID == "" || requestID == tt.requestID) {
+				t.Errorf("request ID = %q, want a generated one", requestID)
+			}
+			if handlerRequestID != requestID {
+				t.Errorf("request ID in the context = %q, want %q", handlerRequestID, requestID)
+			}
+
+			var entry struct {
+				Level      string
+				Msg        string
+				RequestID  string `json:"request_id"`
+				Method     string
+				Path       string
+				Status     int
+				Bytes      int
+				RemoteAddr string `json:"remote_addr"`
+				UserID     string `json:"user_id"`
+			}
+			if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+				t.Fatalf("decoding the access log %q: %v", logs.String(), err)
+			}
+			want := entry
+			want.Level, want.Msg, want.RequestID = tt.level, "request", requestID
+			want.Method, want.Path, want.Status, want.Bytes = "GET", "/api/tools/drill", tt.status, 5
+			want.RemoteAddr, want.UserID = "198.51.100.1", "u1"
+			if entry != want {
+				t.Errorf("access log = %+v, want %+v", entry, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// MetricsMiddleware records the count and latency of each request, labelled
+// with the matched route template rather than the raw path. It wraps the
+// router, so that requests matching no route are counted too, and relies on
+// RouteMiddleware to learn the template.
+func MetricsMiddleware(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			r, route := trackRoute(r)
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			m.ObserveHTTPRequest(r.Method, route.template, rw.status, time.Since(start))
+		})
+	}
+}
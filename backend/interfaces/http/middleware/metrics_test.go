@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// newRoutedHandler returns a router with tool routes, wrapped in outer the
+// way routes.Router wraps it
+func newRoutedHandler(outer ...func(http.Handler) http.Handler) http.Handler {
+	r := mux.NewRouter()
+	r.Use(RouteMiddleware)
+	r.HandleFunc("/api/tools/{id}", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+	r.HandleFunc("/api/tools/{id}/blocks/{blockId}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods("DELETE")
+
+	var handler http.Handler = r
+	for _, mw := range outer {
+		handler = mw(handler)
+	}
+	return handler
+}
+
+func TestMetricsMiddlewareLabelsRouteTemplates(t *testing.T) {
+	m := metrics.New()
+	handler := newRoutedHandler(MetricsMiddleware(m))
+
+	for _, req := range []struct{ method, path string }{
+		{"GET", "/api/tools/drill"},
+		{"GET", "/api/tools/saw"},
+		{"DELETE", "/api/tools/drill/blocks/b1"},
+		{"GET", "/api/tools/drill/manual.pdf"},
+		{"GET", "/wp-login.php"},
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	exposition := rec.Body.String()
+
+	for _, want := range []string{
+		`toolrentalclub_http_requests_total{method="GET",route="/api/tools/{id}",status="200"} 2`,
+		`toolrentalclub_http_requests_total{method="DELETE",route="/api/tools/{id}/blocks/{blockId}",status="204"} 1`,
+		`toolrentalclub_http_requests_total{method="GET",route="unmatched",status="404"} 2`,
+		`toolrentalclub_http_request_duration_seconds_count{method="GET",route="/api/tools/{id}"} 2`,
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("metrics do not contain %s", want)
+		}
+	}
+	for _, path := range []string{"drill", "saw", "wp-login"} {
+		if strings.Contains(exposition, path) {
+			t.Errorf("metrics are labelled with the raw path segment %q", path)
+		}
+	}
+}
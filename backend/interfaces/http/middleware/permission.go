@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/domain/user"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+)
+
+// errInsufficientPermissions is returned when the user's roles lack a permission
+var errInsufficientPermissions = errs.Forbidden("insufficient_permissions", "insufficient permissions")
+
+// RequirePermission creates middleware that only lets requests through when
+// the authenticated user's roles grant the permission. It must run after the
+// auth middleware.
+func RequirePermission(p user.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := auth.PrincipalFromContext(r.Context())
+			if !ok {
+				response.Error(w, r, auth.ErrUnauthenticated)
+				return
+			}
+
+			if !principal.Can(p) {
+				response.Error(w, r, errInsufficientPermissions)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/toolrentalclub/domain/auth"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// RateLimit is a token bucket that holds Requests tokens and refills them
+// evenly over Period, allowing bursts of up to Requests requests
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled reports whether the limit restricts anything; a zero limit does not
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// RateLimitResult is the state of a bucket after taking a token from it
+type RateLimitResult struct {
+	// Allowed is false when the bucket was empty and the request must be rejected
+	Allowed bool
+
+	// Remaining is the number of whole tokens left in the bucket
+	Remaining int
+
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+
+	// RetryAfter is how long until the next token is available when the
+	// request was rejected
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the token buckets. The in-memory store limits each
+// API instance separately; a store shared between instances, such as one
+// backed by Redis, enforces the limits across all of them.
+type RateLimitStore interface {
+	// Take removes a token from the bucket identified by key
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns the identity a request is counted against
+type RateLimitKeyFunc func(r *http.Request) string
+
+// ClientIPKey counts requests against the client address
+func ClientIPKey(proxies *TrustedProxies) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		return "ip:" + proxies.ClientIP(r)
+	}
+}
+
+// UserKey counts requests against the authenticated user, falling back to
+// the client address for anonymous requests. It must run after the auth
+// middleware.
+func UserKey(proxies *TrustedProxies) RateLimitKeyFunc {
+	return func(r *http.Request) string {
+		if principal, ok := auth.PrincipalFromContext(r.Context()); ok {
+			return "user:" + principal.UserID
+		}
+		return "ip:" + proxies.ClientIP(r)
+	}
+}
+
+// RateLimitMiddleware creates middleware that limits the requests of each key
+// to a route group. Every response carries the RateLimit-* headers; requests
+// over the limit are rejected with 429 and Retry-After. When the store fails
+// the request is let through, so that an outage of a shared store does not
+// take the API down with it.
+func RateLimitMiddleware(store RateLimitStore, group string, limit RateLimit, key RateLimitKeyFunc, m *metrics.Metrics) func(http.Handler) http.Handler {
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := store.Take(r.Context(), group+":"+key(r), limit)
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("rate limit store failed, allowing request",
+					"group", group, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+			h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			h.Set("RateLimit-Reset", seconds(result.Reset))
+			h.Set("RateLimit-Policy", policy)
+
+			if !result.Allowed {
+				m.ObserveRateLimited(group)
+				h.Set("Retry-After", seconds(result.RetryAfter))
+				response.Problem(w, r, http.StatusTooManyRequests, "rate_limited", "too many requests; retry after "+h.Get("Retry-After")+" seconds")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// seconds formats d as whole seconds, rounded up so that clients never retry early
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	limit := RateLimit{Requests: 3, Period: 3 * time.Second} // one token per second
+
+	// step takes a token after advancing the clock by wait
+	type step struct {
+		wait       time.Duration
+		key        string
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst up to the capacity",
+			steps: []step{
+				{key: "a", allowed: true, remaining: 2},
+				{key: "a", allowed: true, remaining: 1},
+				{key: "a", allowed: true, remaining: 0},
+				{key: "a", allowed: false, remaining: 0, retryAfter: time.Second},
+			},
+		},
+		{
+			name: "refill one token per period share",
+			steps: []step{
+				{key: "a", allowed: true, remaining: 2},
+				{key: "a", allowed: true, remaining: 1},
+				{key: "a", allowed: true, remaining: 0},
+				{wait: 500 * time.Millisecond, key: "a", allowed: false, remaining: 0, retryAfter: 500 * time.Millisecond},
+				{wait: 500 * time.Millisecond, key: "a", allowed: true, remaining: 0},
+			},
+		},
+		{
+			name: "refill stops at the capacity",
+			steps: []step{
+				{key: "a", allowed: true, remaining: 2},
+				{wait: time.Hour, key: "a", allowed: true, remaining: 2},
+			},
+		},
+		{
+			name: "keys have separate buckets",
+			steps: []step{
+				{key: "a", allowed: true, remaining: 2},
+				{key: "a", allowed: true, remaining: 1},
+				{key: "a", allowed: true, remaining: 0},
+				{key: "b", allowed: true, remaining: 2},
+				{key: "a", allowed: false, remaining: 0, retryAfter: time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+			store := NewMemoryRateLimitStore()
+			store.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.wait)
+				result, err := store.Take(context.Background(), s.key, limit)
+				if err != nil {
+					t.Fatalf("step %d: Take() = %v", i, err)
+				}
+				if result.Allowed != s.allowed || result.Remaining != s.remaining || result.RetryAfter != s.retryAfter {
+					t.Errorf("step %d: Take() = allowed %t, remaining %d, retry after %s; want %t, %d, %s",
+						i, result.Allowed, result.Remaining, result.RetryAfter, s.allowed, s.remaining, s.retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	limit := RateLimit{Requests: 2, Period: time.Second}
+	now := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+
+	if _, err := store.Take(context.Background(), "idle", limit); err != nil {
+		t.Fatalf("Take() = %v", err)
+	}
+
+	// The idle bucket has long refilled by the next sweep
+	now = now.Add(2 * sweepInterval)
+	if _, err := store.Take(context.Background(), "busy", limit); err != nil {
+		t.Fatalf("Take() = %v", err)
+	}
+
+	if _, ok := store.buckets["idle"]; ok {
+		t.Error("full bucket was not swept")
+	}
+	if _, ok := store.buckets["busy"]; !ok {
+		t.Error("bucket in use was swept")
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unmatchedRoute labels requests that did not match any route
+const unmatchedRoute = "unmatched"
+
+// matchedRoute carries the route template of a request out of the router,
+// to middleware that wraps the router and so runs before routing
+type matchedRoute struct {
+	template string
+}
+
+type matchedRouteKey struct{}
+
+// trackRoute returns r with a matchedRoute for RouteMiddleware to fill in,
+// reusing the one an outer middleware already added
+func trackRoute(r *http.Request) (*http.Request, *matchedRoute) {
+	if route, ok := r.Context().Value(matchedRouteKey{}).(*matchedRoute); ok {
+		return r, route
+	}
+	route := &matchedRoute{template: unmatchedRoute}
+	return r.WithContext(context.WithValue(r.Context(), matchedRouteKey{}, route)), route
+}
+
+// RouteMiddleware records the template of the route that matched a request,
+// for the metrics and tracing middleware wrapping the router, and names the
+// request's span after it. It must be attached with Router.Use so that it
+// runs once the route is known; requests that match no route keep the
+// unmatched label.
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if template, ok := currentTemplate(r); ok {
+			if route, ok := r.Context().Value(matchedRouteKey{}).(*matchedRoute); ok {
+				route.template = template
+			}
+
+			span := trace.SpanFromContext(r.Context())
+			span.SetName(r.Method + " " + template)
+			span.SetAttributes(semconv.HTTPRoute(template))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// currentTemplate returns the path template of the route that matched r
+func currentTemplate(r *http.Request) (string, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "", false
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "", false
+	}
+	return template, true
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// TracingMiddleware starts a server span for each request, continuing any
+// trace propagated by the caller. It wraps the router, so the span starts out
+// named after the method alone; RouteMiddleware renames it after the route
+// template once routing has matched one, to group requests to the same
+// endpoint.
+func TracingMiddleware(next http.Handler) http.Handler {
+	return otelhttp.NewMiddleware("http.request",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+	)(next)
+}
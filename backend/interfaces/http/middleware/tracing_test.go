@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+func TestTracingMiddlewareNamesSpansAfterRoutes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	handler := newRoutedHandler(TracingMiddleware)
+
+	tests := []struct {
+		method, path string
+		wantName     string
+		wantRoute    string
+	}{
+		{"GET", "/api/tools/drill", "GET /api/tools/{id}", "/api/tools/{id}"},
+		{"DELETE", "/api/tools/drill/blocks/b1", "DELETE /api/tools/{id}/blocks/{blockId}", "/api/tools/{id}/blocks/{blockId}"},
+		{"GET", "/wp-login.php", "GET", ""},
+	}
+	for _, tt := range tests {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != len(tests) {
+		t.Fatalf("recorded %d spans, want %d", len(spans), len(tests))
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Name() != tt.wantName {
+			t.Errorf("span of %s %s is named %q, want %q", tt.method, tt.path, span.Name(), tt.wantName)
+		}
+
+		route := ""
+		for _, attr := range span.Attributes() {
+			if attr.Key == semconv.HTTPRouteKey {
+				route = attr.Value.AsString()
+			}
+		}
+		if route != tt.wantRoute {
+			t.Errorf("span of %s %s has %s %q, want %q", tt.method, tt.path, semconv.HTTPRouteKey, route, tt.wantRoute)
+		}
+	}
+}
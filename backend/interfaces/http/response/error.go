@@ -0,0 +1,65 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// statusByKind maps each kind of domain error to its HTTP status
+var statusByKind = map[errs.Kind]int{
+	errs.KindNotFound:        http.StatusNotFound,
+	errs.KindConflict:        http.StatusConflict,
+	errs.KindValidation:      http.StatusBadRequest,
+	errs.KindForbidden:       http.StatusForbidden,
+	errs.KindUnauthenticated: http.StatusUnauthorized,
+	errs.KindUnavailable:     http.StatusServiceUnavailable,
+}
+
+// internalError answers every error that is not a domain error
+var internalError = errs.New(errs.KindInternal, "internal_error", "internal server error")
+
+// Error writes err as an RFC 7807 problem. This is the one place where
+// domain errors become responses: the status follows from the error's kind
+// and the code is passed on unchanged. Errors that are not domain errors are
+// logged and answered with a generic 500, so their details never leak.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	domainErr, ok := errs.As(err)
+	if !ok {
+		logging.FromContext(r.Context()).Error("request failed", "error", err)
+		domainErr = internalError
+	}
+
+	status, ok := statusByKind[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	problem := dto.ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail(err, domainErr),
+		Instance: r.URL.Path,
+		Code:     domainErr.Code,
+	}
+	for _, f := range domainErr.Fields {
+		problem.Errors = append(problem.Errors, dto.FieldErrorResponse{Field: f.Field, Message: f.Message})
+	}
+
+	writeProblem(w, r, problem)
+}
+
+// detail describes the error for the client. Context added around a domain
+// error, such as the states of a refused rental transition, is kept; for
+// authentication and availability failures only the domain error's own
+// message is shown, since the rest may describe the provider's internals.
+func detail(err error, domainErr *errs.Error) string {
+	switch domainErr.Kind {
+	case errs.KindInternal, errs.KindUnauthenticated, errs.KindUnavailable:
+		return domainErr.Error()
+	}
+	return err.Error()
+}
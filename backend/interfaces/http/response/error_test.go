@@ -0,0 +1,91 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/toolrentalclub/domain/errs"
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+)
+
+func TestError(t *testing.T) {
+	notFound := errs.NotFound("tool_not_found", "tool not found")
+	invalid := errs.Validation("invalid_tool", "invalid tool")
+	unavailable := errs.Unavailable("provider_unavailable", "authentication provider not available")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+		detail string
+		fields []dto.FieldErrorResponse
+	}{
+		{"not found", notFound, http.StatusNotFound, "tool_not_found", "tool not found", nil},
+		{"conflict", errs.Conflict("rental_conflict", "overlaps"), http.StatusConflict, "rental_conflict", "overlaps", nil},
+		{"validation with fields", invalid.WithField("name", "is required").WithField("dailyRateCents", "must not be negative"),
+			http.StatusBadRequest, "invalid_tool", "invalid tool: name is required, dailyRateCents must not be negative",
+			[]dto.FieldErrorResponse{{Field: "name", Message: "is required"}, {Field: "dailyRateCents", Message: "must not be negative"}}},
+		{"forbidden", errs.Forbidden("not_owner", "not the owner"), http.StatusForbidden, "not_owner", "not the owner", nil},
+		{"unauthenticated", errs.Unauthenticated("invalid_token", "invalid token"), http.StatusUnauthorized, "invalid_token", "invalid token", nil},
+		{"unavailable", unavailable, http.StatusServiceUnavailable, "provider_unavailable", unavailable.Error(), nil},
+		{"wrapped keeps its context", fmt.Errorf("drill: %w", notFound), http.StatusNotFound, "tool_not_found", "drill: tool not found", nil},
+		{"wrapped unavailable hides the cause", fmt.Errorf("dial tcp 10.0.0.5:443: %w", unavailable),
+			http.StatusServiceUnavailable, "provider_unavailable", unavailable.Error(), nil},
+		{"internal kind", errs.New(errs.KindInternal, "broken", "broken"), http.StatusInternalServerError, "broken", "broken", nil},
+		{"not a domain error", errors.New("pq: password authentication failed"),
+			http.StatusInternalServerError, "internal_error", "internal server error", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Error(rec, httptest.NewRequest(http.MethodGet, "/api/tools/drill", nil), tt.err)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Content-Type"); got != ContentTypeProblem {
+				t.Errorf("Content-Type = %q, want %q", got, ContentTypeProblem)
+			}
+
+			var problem dto.ProblemResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decoding %s: %v", rec.Body, err)
+			}
+			want := dto.ProblemResponse{
+				Type:     "about:blank",
+				Title:    http.StatusText(tt.status),
+				Status:   tt.status,
+				Detail:   tt.detail,
+				Instance: "/api/tools/drill",
+				Code:     tt.code,
+				Errors:   tt.fields,
+			}
+			if !reflect.DeepEqual(problem, want) {
+				t.Errorf("problem = %+v, want %+v", problem, want)
+			}
+		})
+	}
+}
+
+// TestStatusByKind checks every kind of domain error has a status of its own
+func TestStatusByKind(t *testing.T) {
+	kinds := []errs.Kind{
+		errs.KindNotFound, errs.KindConflict, errs.KindValidation,
+		errs.KindForbidden, errs.KindUnauthenticated, errs.KindUnavailable,
+	}
+	for _, kind := range kinds {
+		status, ok := statusByKind[kind]
+		if !ok || status < 400 || status == http.StatusInternalServerError {
+			t.Errorf("kind %s maps to %d, want a specific error status", kind, status)
+		}
+	}
+	if _, ok := statusByKind[errs.KindInternal]; ok {
+		t.Errorf("kind %s is mapped, want it to fall back to 500", errs.KindInternal)
+	}
+}
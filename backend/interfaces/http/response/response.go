@@ -0,0 +1,196 @@
+// Package response writes the JSON bodies of the HTTP API. Handlers and
+// middleware both go through it, so every response is encoded the same way,
+// honours the Accept header and the pretty query parameter, and failures to
+// encode or write are logged rather than silently dropped.
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/toolrentalclub/interfaces/http/dto"
+	"github.com/yourusername/toolrentalclub/pkg/logging"
+)
+
+// Media types written by this package
+const (
+	ContentTypeJSON    = "application/json"
+	ContentTypeProblem = "application/problem+json"
+)
+
+// PrettyParam is the query parameter that asks for indented JSON, e.g. ?pretty
+const PrettyParam = "pretty"
+
+// JSON writes payload as JSON with the given status. Clients whose Accept
+// header rules out JSON get 406 Not Acceptable instead.
+func JSON(w http.ResponseWriter, r *http.Request, status int, payload interface{}) {
+	if !Accepts(r, ContentTypeJSON) {
+		NotAcceptable(w, r, ContentTypeJSON)
+		return
+	}
+
+	body, err := encode(r, payload)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("failed to encode response", "error", err)
+		Problem(w, r, http.StatusInternalServerError, internalError.Code, internalError.Message)
+		return
+	}
+
+	write(w, r, status, ContentTypeJSON, body)
+}
+
+// Content writes body with the given status and media type, for the few
+// resources that are also offered in a format other than JSON. Callers pick
+// the media type with Negotiate.
+func Content(w http.ResponseWriter, r *http.Request, status int, contentType string, body []byte) {
+	write(w, r, status, contentType, body)
+}
+
+// NotAcceptable writes a 406 problem listing the media types the resource is
+// available as
+func NotAcceptable(w http.ResponseWriter, r *http.Request, offers ...string) {
+	Problem(w, r, http.StatusNotAcceptable, "not_acceptable", "this resource is only available as "+strings.Join(offers, " or "))
+}
+
+// Problem writes an RFC 7807 problem with the given status, stable code and
+// human-readable detail
+func Problem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	writeProblem(w, r, dto.ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     code,
+	})
+}
+
+// writeProblem writes a problem as application/problem+json, or as plain
+// application/json to clients that do not accept the former. An error is
+// never turned into a 406, which would hide it.
+func writeProblem(w http.ResponseWriter, r *http.Request, problem dto.ProblemResponse) {
+	contentType := ContentTypeProblem
+	if !Accepts(r, ContentTypeProblem) && Accepts(r, ContentTypeJSON) {
+		contentType = ContentTypeJSON
+	}
+	problem.RequestID = logging.RequestIDFromContext(r.Context())
+
+	body, err := encode(r, problem)
+	if err != nil {
+		// A problem only holds strings and ints, so this cannot happen
+		logging.FromContext(r.Context()).Error("failed to encode problem", "error", err)
+		body = []byte(`{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error"}`)
+		problem.Status = http.StatusInternalServerError
+	}
+
+	write(w, r, problem.Status, contentType, body)
+}
+
+// encode marshals payload, indented when the request asks for it
+func encode(r *http.Request, payload interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Bodies are never embedded in HTML, and escaping & would mangle URLs such as pagination links
+	enc.SetEscapeHTML(false)
+	if pretty(r) {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// write sends the response, logging the failure if the client has gone away
+func write(w http.ResponseWriter, r *http.Request, status int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	if _, err := w.Write(body); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to write response", "status", status, "error", err)
+	}
+}
+
+// pretty reports whether the request asks for indented JSON: ?pretty,
+// ?pretty=1 and ?pretty=true do, ?pretty=false does not
+func pretty(r *http.Request) bool {
+	query := r.URL.Query()
+	if !query.Has(PrettyParam) {
+		return false
+	}
+	value := query.Get(PrettyParam)
+	if value == "" {
+		return true
+	}
+	on, err := strconv.ParseBool(value)
+	return err == nil && on
+}
+
+// Accepts reports whether the request's Accept header allows mediaType;
+// a missing header accepts everything
+func Accepts(r *http.Request, mediaType string) bool {
+	return quality(r, mediaType) > 0
+}
+
+// Negotiate returns the offered media type the request's Accept header
+// prefers, or "" if it allows none of them. Offers are media types without
+// parameters; ties go to the earlier offer.
+func Negotiate(r *http.Request, offers ...string) string {
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		if q := quality(r, offer); q > bestQuality {
+			best, bestQuality = offer, q
+		}
+	}
+	return best
+}
+
+// quality returns the weight the request's Accept header gives mediaType:
+// the q value of the most specific media range that matches it, 0 if none
+// does, and 1 if there is no header
+func quality(r *http.Request, mediaType string) float64 {
+	header := r.Header.Values("Accept")
+	if len(header) == 0 {
+		return 1
+	}
+
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	weight, specificity := 0.0, -1
+	for _, accepted := range strings.Split(strings.Join(header, ","), ",") {
+		accepted, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		acceptedType, acceptedSubtype, _ := strings.Cut(accepted, "/")
+		var matched int
+		switch {
+		case acceptedType == "*" && acceptedSubtype == "*":
+			matched = 0
+		case acceptedType != typ:
+			continue
+		case acceptedSubtype == "*":
+			matched = 1
+		case acceptedSubtype == subtype:
+			matched = 2
+		default:
+			continue
+		}
+		if matched <= specificity {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		weight, specificity = q, matched
+	}
+	return weight
+}
@@ -0,0 +1,134 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newRequest(accept ...string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/api/tools", nil)
+	for _, a := range accept {
+		r.Header.Add("Accept", a)
+	}
+	return r
+}
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{ContentTypeJSON, "text/calendar"}
+
+	tests := []struct {
+		name   string
+		accept []string
+		want   string
+	}{
+		{"no header", nil, ContentTypeJSON},
+		{"anything", []string{"*/*"}, ContentTypeJSON},
+		{"exact", []string{"text/calendar"}, "text/calendar"},
+		{"type wildcard", []string{"text/*"}, "text/calendar"},
+		{"higher quality wins", []string{"application/json;q=0.4, text/calendar;q=0.8"}, "text/calendar"},
+		{"tie goes to the first offer", []string{"text/calendar, application/json"}, ContentTypeJSON},
+		{"specific range overrides wildcard", []string{"*/*;q=0.9, application/json;q=0.1"}, "text/calendar"},
+		{"excluded by q=0", []string{"*/*, application/json;q=0"}, "text/calendar"},
+		{"header over several lines", []string{"text/html", "text/calendar"}, "text/calendar"},
+		{"malformed ranges are skipped", []string{"garbage;;, text/calendar"}, "text/calendar"},
+		{"nothing acceptable", []string{"text/html, image/*"}, ""},
+		{"everything refused", []string{"*/*;q=0"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(newRequest(tt.accept...), offers...); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      []string
+		status      int
+		contentType string
+	}{
+		{"no header", nil, http.StatusCreated, ContentTypeJSON},
+		{"JSON", []string{"application/json"}, http.StatusCreated, ContentTypeJSON},
+		{"application wildcard", []string{"application/*"}, http.StatusCreated, ContentTypeJSON},
+		{"HTML only", []string{"text/html"}, http.StatusNotAcceptable, ContentTypeProblem},
+		{"JSON refused", []string{"*/*, application/json;q=0"}, http.StatusNotAcceptable, ContentTypeProblem},
+		{"problems only", []string{"application/problem+json"}, http.StatusNotAcceptable, ContentTypeProblem},
+		{"JSON and problems refused", []string{"application/json;q=0, application/problem+json;q=0, */*"}, http.StatusNotAcceptable, ContentTypeProblem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			JSON(rec, newRequest(tt.accept...), http.StatusCreated, map[string]string{"id": "drill"})
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if tt.status == http.StatusNotAcceptable && !strings.Contains(rec.Body.String(), `"code":"not_acceptable"`) {
+				t.Errorf("body = %s, want a not_acceptable problem", rec.Body)
+			}
+		})
+	}
+}
+
+func TestProblemContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   string
+	}{
+		{"no header", nil, ContentTypeProblem},
+		{"problems", []string{"application/problem+json"}, ContentTypeProblem},
+		{"JSON only", []string{"application/json"}, ContentTypeJSON},
+		// An error is never turned into a 406
+		{"neither", []string{"text/html"}, ContentTypeProblem},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Problem(rec, newRequest(tt.accept...), http.StatusConflict, "rental_conflict", `tool is "booked"`)
+
+			if rec.Code != http.StatusConflict {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusConflict)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.want {
+				t.Errorf("Content-Type = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(rec.Body.String(), `"detail":"tool is \"booked\""`) {
+				t.Errorf("body = %s, want the detail JSON-escaped", rec.Body)
+			}
+		})
+	}
+}
+
+func TestPretty(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", false},
+		{"?pretty", true},
+		{"?pretty=1", true},
+		{"?pretty=true", true},
+		{"?pretty=false", false},
+		{"?pretty=yes", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		JSON(rec, httptest.NewRequest(http.MethodGet, "/api/tools"+tt.query, nil), http.StatusOK, map[string]string{"id": "a&b"})
+
+		if got := strings.Contains(rec.Body.String(), "\n  "); got != tt.want {
+			t.Errorf("JSON() with %q indented = %t, want %t: %s", tt.query, got, tt.want, rec.Body)
+		}
+		if !strings.Contains(rec.Body.String(), "a&b") {
+			t.Errorf("JSON() escaped HTML characters: %s", rec.Body)
+		}
+	}
+}
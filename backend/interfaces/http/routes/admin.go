@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// registerAdminRoutes sets up club administration endpoints
+// These routes require authentication and a staff or admin permission;
+// changing roles or account statuses also requires a token that has not
+// been revoked
+func (rt *Router) registerAdminRoutes(r *mux.Router) {
+	adminRouter := r.PathPrefix("/api/admin").Subrouter()
+	rt.requireAuth(adminRouter)
+
+	// GET /api/admin/users - Search and page through users (staff)
+	adminRouter.Handle("/users",
+		rt.requirePermission(user.PermissionViewUsers, rt.adminHandler.ListUsers)).Methods("GET")
+
+	// GET /api/admin/users/{id}/roles - View a user's roles (staff)
+	adminRouter.Handle("/users/{id}/roles",
+		rt.requirePermission(user.PermissionViewUsers, rt.adminHandler.GetUserRoles)).Methods("GET")
+
+	// POST /api/admin/users/{id}/roles - Grant a role (admin)
+	adminRouter.Handle("/users/{id}/roles",
+		rt.requirePermission(user.PermissionManageRoles, rt.checkRevoked(rt.adminHandler.GrantRole))).Methods("POST")
+
+	// DELETE /api/admin/users/{id}/roles/{role} - Revoke a role (admin)
+	adminRouter.Handle("/users/{id}/roles/{role}",
+		rt.requirePermission(user.PermissionManageRoles, rt.checkRevoked(rt.adminHandler.RevokeRole))).Methods("DELETE")
+
+	// POST /api/admin/users/{id}/suspend - Suspend an account (staff)
+	adminRouter.Handle("/users/{id}/suspend",
+		rt.requirePermission(user.PermissionManageMembers, rt.checkRevoked(rt.adminHandler.SuspendUser))).Methods("POST")
+
+	// POST /api/admin/users/{id}/reinstate - Approve or reinstate an account (staff)
+	adminRouter.Handle("/users/{id}/reinstate",
+		rt.requirePermission(user.PermissionManageMembers, rt.checkRevoked(rt.adminHandler.ReinstateUser))).Methods("POST")
+
+	// POST /api/admin/users/{id}/close - Close an account (staff)
+	adminRouter.Handle("/users/{id}/close",
+		rt.requirePermission(user.PermissionManageMembers, rt.checkRevoked(rt.adminHandler.CloseUser))).Methods("POST")
+}
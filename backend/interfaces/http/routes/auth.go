@@ -6,7 +6,27 @@ import "github.com/gorilla/mux"
 // These routes handle token verification and other auth operations
 func (rt *Router) registerAuthRoutes(r *mux.Router) {
 	authRouter := r.PathPrefix("/api/auth").Subrouter()
+	rt.rateLimitByIP(authRouter, "auth", rt.rateLimits.Auth)
 
-	// POST /api/auth/verify - Verify Firebase token
+	// POST /api/auth/verify - Verify an access token
 	authRouter.HandleFunc("/verify", rt.authHandler.VerifyToken).Methods("POST")
+
+	if rt.accountHandler == nil {
+		return
+	}
+
+	// POST /api/auth/register - Create a password account
+	authRouter.HandleFunc("/register", rt.accountHandler.Register).Methods("POST")
+
+	// POST /api/auth/login - Exchange email and password for an access token
+	authRouter.HandleFunc("/login", rt.accountHandler.Login).Methods("POST")
+
+	// POST /api/auth/password-reset - Email a password reset link
+	authRouter.HandleFunc("/password-reset", rt.accountHandler.RequestPasswordReset).Methods("POST")
+
+	// POST /api/auth/password-reset/confirm - Set a new password with a reset token
+	authRouter.HandleFunc("/password-reset/confirm", rt.accountHandler.ResetPassword).Methods("POST")
+
+	// GET /.well-known/jwks.json - Public keys that verify issued tokens
+	r.HandleFunc("/.well-known/jwks.json", rt.accountHandler.JWKS).Methods("GET")
 }
@@ -5,6 +5,12 @@ import "github.com/gorilla/mux"
 // registerHealthRoutes sets up all health-related endpoints
 // These routes are public and do not require authentication
 func (rt *Router) registerHealthRoutes(r *mux.Router) {
-	// GET /api/health - Health check endpoint
+	// GET /api/health - Health check endpoint, reporting readiness
 	r.HandleFunc("/api/health", rt.healthHandler.HealthCheck).Methods("GET")
+
+	// GET /api/health/live - Liveness probe
+	r.HandleFunc("/api/health/live", rt.healthHandler.Live).Methods("GET")
+
+	// GET /api/health/ready - Readiness probe with per-dependency status
+	r.HandleFunc("/api/health/ready", rt.healthHandler.Ready).Methods("GET")
 }
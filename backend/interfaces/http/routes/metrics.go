@@ -0,0 +1,10 @@
+package routes
+
+import "github.com/gorilla/mux"
+
+// registerMetricsRoutes exposes the Prometheus metrics
+// This route is public; restrict access to it at the proxy if needed
+func (rt *Router) registerMetricsRoutes(r *mux.Router) {
+	// GET /metrics - Prometheus scrape endpoint
+	r.Handle("/metrics", rt.metrics.Handler()).Methods("GET")
+}
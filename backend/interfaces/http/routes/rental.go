@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// registerRentalRoutes sets up all reservation endpoints
+// These routes are protected by the auth middleware
+func (rt *Router) registerRentalRoutes(r *mux.Router) {
+	rentalRouter := r.PathPrefix("/api/rentals").Subrouter()
+	rt.requireAuth(rentalRouter)
+
+	// POST /api/rentals - Reserve a tool for a date range
+	rentalRouter.Handle("", rt.requirePermission(user.PermissionRentTools, rt.rentalHandler.CreateRental)).Methods("POST")
+
+	// GET /api/rentals/{id} - Get a reservation (renter or tool owner only)
+	rentalRouter.HandleFunc("/{id}", rt.rentalHandler.GetRental).Methods("GET")
+
+	// POST /api/rentals/{id}/confirm - Tool owner confirms a request
+	rentalRouter.HandleFunc("/{id}/confirm", rt.rentalHandler.ConfirmRental).Methods("POST")
+
+	// POST /api/rentals/{id}/pickup - Tool owner records the pick-up
+	rentalRouter.HandleFunc("/{id}/pickup", rt.rentalHandler.PickUpRental).Methods("POST")
+
+	// POST /api/rentals/{id}/return - Tool owner records the return
+	rentalRouter.HandleFunc("/{id}/return", rt.rentalHandler.ReturnRental).Methods("POST")
+
+	// POST /api/rentals/{id}/cancel - Renter or tool owner cancels
+	rentalRouter.HandleFunc("/{id}/cancel", rt.rentalHandler.CancelRental).Methods("POST")
+}
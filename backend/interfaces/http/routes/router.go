@@ -1,11 +1,16 @@
 package routes
 
 import (
+	"log/slog"
+	"net/http"
+
 	"github.com/gorilla/mux"
 
-	authApp "github.com/yourusername/toolrentalclub/application/auth"
+	"github.com/yourusername/toolrentalclub/domain/user"
 	"github.com/yourusername/toolrentalclub/interfaces/http/handlers"
 	"github.com/yourusername/toolrentalclub/interfaces/http/middleware"
+	"github.com/yourusername/toolrentalclub/interfaces/http/response"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
 )
 
 // Router holds all the dependencies needed for route registration
@@ -13,8 +18,47 @@ type Router struct {
 	healthHandler *handlers.HealthHandler
 	authHandler   *handlers.AuthHandler
 	userHandler   *handlers.UserHandler
-	authUseCase   *authApp.UseCase
-	authEnabled   bool
+	toolHandler   *handlers.ToolHandler
+	rentalHandler *handlers.RentalHandler
+	adminHandler  *handlers.AdminHandler
+
+	// accountHandler is nil unless the self-hosted auth provider is used
+	accountHandler *handlers.AccountHandler
+
+	// authMiddleware authenticates requests to protected routes
+	authMiddleware mux.MiddlewareFunc
+
+	// revocationCheck additionally rejects revoked tokens on sensitive
+	// routes; nil when the provider cannot revoke tokens or checks are off
+	revocationCheck mux.MiddlewareFunc
+
+	// logger writes the access log and trustedProxies decide whose
+	// X-Forwarded-For header reveals the client address
+	logger         *slog.Logger
+	trustedProxies *middleware.TrustedProxies
+
+	// metrics records request metrics and is exposed on /metrics
+	metrics *metrics.Metrics
+
+	// cors decides which browser origins may call the API
+	cors *middleware.CORSPolicy
+
+	// rateLimits throttle each route group
+	rateLimits RateLimits
+}
+
+// RateLimits configures the rate limit of each route group. Auth and Public
+// are counted per client IP and User per authenticated user, across all
+// protected routes. UserIP is counted per client IP across all protected
+// routes before the token is verified, so that requests with made-up tokens
+// are throttled before they reach the identity provider. A zero limit leaves
+// the group unlimited.
+type RateLimits struct {
+	Store  middleware.RateLimitStore
+	Auth   middleware.RateLimit
+	Public middleware.RateLimit
+	User   middleware.RateLimit
+	UserIP middleware.RateLimit
 }
 
 // NewRouter creates a new Router with all required dependencies
@@ -22,30 +66,112 @@ func NewRouter(
 	healthHandler *handlers.HealthHandler,
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
-	authUseCase *authApp.UseCase,
-	authEnabled bool,
+	toolHandler *handlers.ToolHandler,
+	rentalHandler *handlers.RentalHandler,
+	adminHandler *handlers.AdminHandler,
+	accountHandler *handlers.AccountHandler,
+	authMiddleware mux.MiddlewareFunc,
+	revocationCheck mux.MiddlewareFunc,
+	logger *slog.Logger,
+	trustedProxies *middleware.TrustedProxies,
+	metrics *metrics.Metrics,
+	cors *middleware.CORSPolicy,
+	rateLimits RateLimits,
 ) *Router {
 	return &Router{
-		healthHandler: healthHandler,
-		authHandler:   authHandler,
-		userHandler:   userHandler,
-		authUseCase:   authUseCase,
-		authEnabled:   authEnabled,
+		healthHandler:   healthHandler,
+		authHandler:     authHandler,
+		userHandler:     userHandler,
+		toolHandler:     toolHandler,
+		rentalHandler:   rentalHandler,
+		adminHandler:    adminHandler,
+		accountHandler:  accountHandler,
+		authMiddleware:  authMiddleware,
+		revocationCheck: revocationCheck,
+		logger:          logger,
+		trustedProxies:  trustedProxies,
+		metrics:         metrics,
+		cors:            cors,
+		rateLimits:      rateLimits,
 	}
 }
 
-// Setup creates and configures the main router with all routes and middleware
-func (rt *Router) Setup() *mux.Router {
+// Setup creates and configures the main router with all routes and
+// middleware. CORS wraps the router itself so that preflight requests, which
+// match no route's methods, are answered too. Tracing, logging and metrics
+// wrap CORS in turn, so that preflights and requests answered with 404 or 405
+// are traced, logged and counted like any other.
+func (rt *Router) Setup() http.Handler {
 	r := mux.NewRouter()
+	r.NotFoundHandler = http.HandlerFunc(notFound)
+	r.MethodNotAllowedHandler = http.HandlerFunc(methodNotAllowed)
 
-	// Apply global middleware
-	r.Use(middleware.CORSMiddleware)
-	r.Use(middleware.LoggingMiddleware)
+	// Tell the middleware wrapping the router which route matched
+	r.Use(middleware.RouteMiddleware)
 
 	// Register all route groups
 	rt.registerHealthRoutes(r)
+	rt.registerMetricsRoutes(r)
 	rt.registerAuthRoutes(r)
+	rt.registerToolRoutes(r)
+	rt.registerRentalRoutes(r)
+	rt.registerAdminRoutes(r)
 	rt.registerProtectedRoutes(r)
 
-	return r
+	var handler http.Handler = r
+	handler = middleware.CORSMiddleware(rt.cors)(handler)
+	handler = middleware.MetricsMiddleware(rt.metrics)(handler)
+	handler = middleware.LoggingMiddleware(rt.logger, rt.trustedProxies)(handler)
+	return middleware.TracingMiddleware(handler)
+}
+
+// requireAuth applies the per-IP rate limit, the authentication middleware
+// and the per-user rate limit to a subrouter, in that order. Without an auth
+// middleware, the routes reject every request rather than becoming public.
+func (rt *Router) requireAuth(r *mux.Router) {
+	if rt.authMiddleware == nil {
+		r.Use(middleware.DenyAllMiddleware)
+		return
+	}
+	rt.rateLimitByIP(r, "user_ip", rt.rateLimits.UserIP)
+	r.Use(rt.authMiddleware)
+	rt.rateLimit(r, "user", rt.rateLimits.User, middleware.UserKey(rt.trustedProxies))
+}
+
+// rateLimitByIP applies a per-client-IP rate limit to a subrouter
+func (rt *Router) rateLimitByIP(r *mux.Router, group string, limit middleware.RateLimit) {
+	rt.rateLimit(r, group, limit, middleware.ClientIPKey(rt.trustedProxies))
+}
+
+// rateLimit applies a rate limit to a subrouter unless it is disabled
+func (rt *Router) rateLimit(r *mux.Router, group string, limit middleware.RateLimit, key middleware.RateLimitKeyFunc) {
+	if rt.rateLimits.Store == nil || !limit.Enabled() {
+		return
+	}
+	r.Use(middleware.RateLimitMiddleware(rt.rateLimits.Store, group, limit, key, rt.metrics))
+}
+
+// requirePermission wraps a handler so that it only runs for users whose
+// roles grant the permission
+func (rt *Router) requirePermission(p user.Permission, h http.HandlerFunc) http.Handler {
+	return middleware.RequirePermission(p)(h)
+}
+
+// checkRevoked wraps the handler of a sensitive route so that it only runs
+// for tokens that have not been revoked. It must run after requireAuth.
+func (rt *Router) checkRevoked(h http.HandlerFunc) http.HandlerFunc {
+	if rt.revocationCheck == nil {
+		return h
+	}
+	return rt.revocationCheck(h).ServeHTTP
+}
+
+// notFound answers requests that match no route
+func notFound(w http.ResponseWriter, r *http.Request) {
+	response.Problem(w, r, http.StatusNotFound, "route_not_found", "no endpoint matches "+r.URL.Path)
+}
+
+// methodNotAllowed answers requests whose path matches a route but not its method
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	response.Problem(w, r, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not supported by "+r.URL.Path)
 }
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/yourusername/toolrentalclub/interfaces/http/middleware"
+	"github.com/yourusername/toolrentalclub/pkg/metrics"
+)
+
+// TestRequireAuthLimitsByIPFirst checks that requests to protected routes
+// are counted per client IP before their token is verified, so that a
+// client cycling through made-up tokens cannot reach the identity provider
+// once it is over the limit
+func TestRequireAuthLimitsByIPFirst(t *testing.T) {
+	verified := 0
+	rt := &Router{
+		authMiddleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				verified++
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		},
+		metrics: metrics.New(),
+		rateLimits: RateLimits{
+			Store:  middleware.NewMemoryRateLimitStore(),
+			UserIP: middleware.RateLimit{Requests: 2, Period: time.Minute},
+			User:   middleware.RateLimit{Requests: 100, Period: time.Minute},
+		},
+	}
+
+	r := mux.NewRouter()
+	protected := r.PathPrefix("/api").Subrouter()
+	rt.requireAuth(protected)
+	protected.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {}).Methods("GET")
+
+	var codes []int
+	for i := 0; i < 4; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/profile", nil)
+		req.RemoteAddr = "203.0.113.7:41000"
+		req.Header.Set("Authorization", "Bearer made-up")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+	}
+
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusTooManyRequests}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("status codes = %v, want %v", codes, want)
+		}
+	}
+	if verified != 2 {
+		t.Errorf("tokens verified %d times, want 2", verified)
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/yourusername/toolrentalclub/domain/user"
+)
+
+// registerToolRoutes sets up all tool catalog endpoints
+// Browsing the catalog is public; managing inventory requires authentication
+func (rt *Router) registerToolRoutes(r *mux.Router) {
+	catalogRouter := r.PathPrefix("/api/tools").Subrouter()
+	rt.rateLimitByIP(catalogRouter, "public", rt.rateLimits.Public)
+
+	// GET /api/tools - List the tool catalog
+	catalogRouter.HandleFunc("", rt.toolHandler.ListTools).Methods("GET")
+
+	// GET /api/tools/{id} - Get a single tool
+	catalogRouter.HandleFunc("/{id}", rt.toolHandler.GetTool).Methods("GET")
+
+	// GET /api/tools/{id}/availability - Free/busy calendar (JSON or iCalendar)
+	catalogRouter.HandleFunc("/{id}/availability", rt.toolHandler.GetAvailability).Methods("GET")
+
+	toolRouter := r.PathPrefix("/api/tools").Subrouter()
+	rt.requireAuth(toolRouter)
+
+	// POST /api/tools - Add a tool owned by the current user (tool owners)
+	toolRouter.Handle("", rt.requirePermission(user.PermissionListTools, rt.toolHandler.CreateTool)).Methods("POST")
+
+	// PUT /api/tools/{id} - Update a tool owned by the current user
+	toolRouter.HandleFunc("/{id}", rt.toolHandler.UpdateTool).Methods("PUT")
+
+	// DELETE /api/tools/{id} - Remove a tool owned by the current user
+	toolRouter.HandleFunc("/{id}", rt.toolHandler.DeleteTool).Methods("DELETE")
+
+	// GET /api/tools/{id}/blocks - List maintenance and blackout blocks
+	toolRouter.HandleFunc("/{id}/blocks", rt.toolHandler.ListBlocks).Methods("GET")
+
+	// POST /api/tools/{id}/blocks - Block a tool for maintenance or blackout dates
+	toolRouter.HandleFunc("/{id}/blocks", rt.toolHandler.CreateBlock).Methods("POST")
+
+	// DELETE /api/tools/{id}/blocks/{blockId} - Remove a block
+	toolRouter.HandleFunc("/{id}/blocks/{blockId}", rt.toolHandler.DeleteBlock).Methods("DELETE")
+}
@@ -1,21 +1,23 @@
 package routes
 
-import (
-	"github.com/gorilla/mux"
-
-	"github.com/yourusername/toolrentalclub/interfaces/http/middleware"
-)
+import "github.com/gorilla/mux"
 
 // registerProtectedRoutes sets up all routes that require authentication
 // These routes are protected by the auth middleware
 func (rt *Router) registerProtectedRoutes(r *mux.Router) {
 	protectedRouter := r.PathPrefix("/api").Subrouter()
 
-	// Apply authentication middleware if auth is enabled
-	if rt.authEnabled {
-		protectedRouter.Use(middleware.AuthMiddleware(rt.authUseCase))
-	}
+	rt.requireAuth(protectedRouter)
 
 	// GET /api/profile - Get current user's profile
 	protectedRouter.HandleFunc("/profile", rt.userHandler.GetProfile).Methods("GET")
+
+	// PATCH /api/profile - Update fields of the current user's profile
+	protectedRouter.HandleFunc("/profile", rt.userHandler.UpdateProfile).Methods("PATCH")
+
+	// DELETE /api/profile - Delete the current user's account
+	protectedRouter.HandleFunc("/profile", rt.checkRevoked(rt.userHandler.DeleteProfile)).Methods("DELETE")
+
+	// GET /api/profile/export - Download everything stored about the current user
+	protectedRouter.HandleFunc("/profile/export", rt.checkRevoked(rt.userHandler.ExportData)).Methods("GET")
 }
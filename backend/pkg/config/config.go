@@ -3,15 +3,160 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// AuthMode selects how requests to protected routes are authenticated
+type AuthMode string
+
+const (
+	// AuthModeFirebase verifies Firebase ID tokens
+	AuthModeFirebase AuthMode = "firebase"
+
+	// AuthModeJWT verifies tokens issued by the self-hosted JWT provider
+	AuthModeJWT AuthMode = "jwt"
+
+	// AuthModeDevInsecure trusts an identity taken from request headers.
+	// For local development only: anyone can act as any user.
+	AuthModeDevInsecure AuthMode = "dev-insecure"
+
+	// AuthModeNone disables authentication; protected routes reject every request
+	AuthModeNone AuthMode = "none"
+)
+
+// Valid reports whether m is a known auth mode
+func (m AuthMode) Valid() bool {
+	switch m {
+	case AuthModeFirebase, AuthModeJWT, AuthModeDevInsecure, AuthModeNone:
+		return true
+	}
+	return false
+}
+
+// RateLimit allows Requests requests per Period; a zero RateLimit allows everything
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
 // Config holds the application configuration
 type Config struct {
-	Port                     string
-	FirebaseCredentialsJSON  string
-	FirebaseServiceAccount   string
+	Port                    string
+	FirebaseCredentialsJSON string
+	FirebaseServiceAccount  string
+
+	// ShutdownTimeout is how long in-flight requests may take to finish after SIGINT or SIGTERM
+	ShutdownTimeout time.Duration
+
+	// ShutdownDelay is how long the server keeps serving after SIGINT or
+	// SIGTERM, reporting not ready, before it stops accepting connections
+	ShutdownDelay time.Duration
+
+	// LogFormat ("json" or "text") and LogLevel ("debug", "info", "warn" or "error") configure logging
+	LogFormat string
+	LogLevel  string
+
+	// TrustedProxies are the IPs or CIDR ranges of reverse proxies whose
+	// X-Forwarded-For header is trusted to report the client address
+	TrustedProxies []string
+
+	// Tracing settings. TracingExporter is "none", "otlp", "stdout" or "file";
+	// TracingEndpoint is the OTLP collector's host:port and TracingProtocol
+	// its transport ("grpc" or "http/protobuf").
+	TracingExporter    string
+	TracingServiceName string
+	TracingEndpoint    string
+	TracingProtocol    string
+	TracingInsecure    bool
+	TracingFile        string
+	TracingSampleRatio float64
+
+	// CORS policy. Origins may use a subdomain wildcard (https://*.example.com)
+	// or be "*"; credentials cannot be combined with "*".
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSExposedHeaders   []string
+	CORSAllowCredentials bool
+	CORSMaxAge           time.Duration
+
+	// Rate limits of the auth endpoints and public catalog, per client IP, and
+	// of authenticated routes, per user and, before the token is verified,
+	// per client IP. Set as "<requests>/<period>" (e.g. "20/1m") or "off".
+	RateLimitAuth   RateLimit
+	RateLimitPublic RateLimit
+	RateLimitUser   RateLimit
+	RateLimitUserIP RateLimit
+
+	// HealthCheckTimeout bounds each dependency check run by the readiness endpoint
+	HealthCheckTimeout time.Duration
+
+	// StorageBackend selects where data is kept: "memory", "postgres", "sqlite" or "firestore".
+	// Defaults to "postgres" when DatabaseURL is set and "memory" otherwise.
+	StorageBackend string
+
+	// DatabaseURL is a PostgreSQL connection string
+	DatabaseURL string
+
+	// SQLitePath is the database file used by the sqlite backend
+	SQLitePath string
+
+	// AuthMode selects how protected routes are authenticated
+	AuthMode AuthMode
+
+	// AuthTokenCacheSize is how many verified tokens are remembered until they
+	// expire, saving a verification per request; 0 disables the cache
+	AuthTokenCacheSize int
+
+	// AuthCheckRevoked makes sensitive routes, such as role changes, ask
+	// Firebase whether the token has been revoked
+	AuthCheckRevoked bool
+
+	// AuthDeleteProviderAccounts deletes a member's Firebase account when
+	// they delete their club account
+	AuthDeleteProviderAccounts bool
+
+	// DevUserID, DevEmail and DevRoles are the identity used in dev-insecure
+	// mode when a request does not set the X-Dev-User-* headers
+	DevUserID string
+	DevEmail  string
+	DevRoles  []string
+
+	// AdminUserIDs always hold the admin role, which bootstraps the first admin
+	AdminUserIDs []string
+
+	// RequireMemberApproval keeps self-registered accounts pending until
+	// staff reinstate them
+	RequireMemberApproval bool
+
+	// JWTKeysDir holds the PEM signing keys of the jwt provider; the file name
+	// without extension is the key ID. An ephemeral key is generated if empty.
+	JWTKeysDir string
+
+	// JWTActiveKeyID selects the signing key; defaults to the last key ID in lexical order
+	JWTActiveKeyID string
+
+	// JWTIssuer and JWTAudience are the iss and aud claims of issued tokens
+	JWTIssuer   string
+	JWTAudience string
+
+	// JWTTokenTTL is how long access tokens issued by the jwt provider stay valid
+	JWTTokenTTL time.Duration
+
+	// SMTP settings for outgoing email. Emails are written to the log if SMTPHost is empty.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+
+	// PasswordResetURL is the frontend page linked from password reset emails;
+	// the reset token is appended to it
+	PasswordResetURL string
 }
 
 // Load loads the configuration from environment variables
@@ -26,10 +171,200 @@ func Load() *Config {
 		port = "8080"
 	}
 
+	databaseURL := os.Getenv("DATABASE_URL")
+
+	storageBackend := os.Getenv("STORAGE_BACKEND")
+	if storageBackend == "" {
+		storageBackend = "memory"
+		if databaseURL != "" {
+			storageBackend = "postgres"
+		}
+	}
+
+	sqlitePath := os.Getenv("SQLITE_PATH")
+	if sqlitePath == "" {
+		sqlitePath = "toolrentalclub.db"
+	}
+
 	return &Config{
-		Port:                    port,
-		FirebaseCredentialsJSON: os.Getenv("FIREBASE_CREDENTIALS_JSON"),
-		FirebaseServiceAccount:  os.Getenv("FIREBASE_SERVICE_ACCOUNT"),
+		Port:                       port,
+		FirebaseCredentialsJSON:    os.Getenv("FIREBASE_CREDENTIALS_JSON"),
+		FirebaseServiceAccount:     os.Getenv("FIREBASE_SERVICE_ACCOUNT"),
+		ShutdownTimeout:            getDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		ShutdownDelay:              getDuration("SHUTDOWN_DELAY", 0),
+		HealthCheckTimeout:         getDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		LogFormat:                  getEnv("LOG_FORMAT", "json"),
+		LogLevel:                   getEnv("LOG_LEVEL", "info"),
+		TrustedProxies:             getList("TRUSTED_PROXIES"),
+		CORSAllowedOrigins:         splitList(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000")),
+		CORSAllowedMethods:         splitList(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE")),
+		CORSAllowedHeaders:         splitList(getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,X-Request-ID")),
+		CORSExposedHeaders:         splitList(getEnv("CORS_EXPOSED_HEADERS", "X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After")),
+		CORSAllowCredentials:       getBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:                 getDuration("CORS_MAX_AGE", time.Hour),
+		RateLimitAuth:              getRateLimit("RATE_LIMIT_AUTH", RateLimit{Requests: 20, Period: time.Minute}),
+		RateLimitPublic:            getRateLimit("RATE_LIMIT_PUBLIC", RateLimit{Requests: 120, Period: time.Minute}),
+		RateLimitUser:              getRateLimit("RATE_LIMIT_USER", RateLimit{Requests: 300, Period: time.Minute}),
+		RateLimitUserIP:            getRateLimit("RATE_LIMIT_USER_IP", RateLimit{Requests: 600, Period: time.Minute}),
+		TracingExporter:            getEnv("TRACING_EXPORTER", "none"),
+		TracingServiceName:         getEnv("TRACING_SERVICE_NAME", "toolrentalclub-api"),
+		TracingEndpoint:            os.Getenv("TRACING_OTLP_ENDPOINT"),
+		TracingProtocol:            getEnv("TRACING_OTLP_PROTOCOL", "grpc"),
+		TracingInsecure:            getBool("TRACING_OTLP_INSECURE", false),
+		TracingFile:                getEnv("TRACING_FILE", "traces.jsonl"),
+		TracingSampleRatio:         getFloat("TRACING_SAMPLE_RATIO", 1),
+		StorageBackend:             storageBackend,
+		DatabaseURL:                databaseURL,
+		SQLitePath:                 sqlitePath,
+		AuthMode:                   getAuthMode("AUTH_MODE", AuthModeFirebase),
+		AuthTokenCacheSize:         getInt("AUTH_TOKEN_CACHE_SIZE", 10000),
+		AuthCheckRevoked:           getBool("AUTH_CHECK_REVOKED", true),
+		AuthDeleteProviderAccounts: getBool("AUTH_DELETE_PROVIDER_ACCOUNTS", false),
+		DevUserID:                  getEnv("DEV_AUTH_USER_ID", "dev-user"),
+		DevEmail:                   getEnv("DEV_AUTH_EMAIL", "dev@localhost"),
+		DevRoles:                   getList("DEV_AUTH_ROLES"),
+		AdminUserIDs:               getList("ADMIN_USER_IDS"),
+		RequireMemberApproval:      getBool("REQUIRE_MEMBER_APPROVAL", false),
+		JWTKeysDir:                 os.Getenv("JWT_KEYS_DIR"),
+		JWTActiveKeyID:             os.Getenv("JWT_ACTIVE_KID"),
+		JWTIssuer:                  getEnv("JWT_ISSUER", "toolrentalclub"),
+		JWTAudience:                getEnv("JWT_AUDIENCE", "toolrentalclub-api"),
+		JWTTokenTTL:                getDuration("JWT_TTL", time.Hour),
+		SMTPHost:                   os.Getenv("SMTP_HOST"),
+		SMTPPort:                   getEnv("SMTP_PORT", "587"),
+		SMTPUsername:               os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:               os.Getenv("SMTP_PASSWORD"),
+		MailFrom:                   getEnv("MAIL_FROM", "Tool Rental Club <no-reply@localhost>"),
+		PasswordResetURL:           getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password?token="),
 	}
 }
 
+// getEnv returns the environment variable or fallback if it is not set
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getList returns the comma-separated environment variable as a list,
+// ignoring empty entries
+func getList(key string) []string {
+	return splitList(os.Getenv(key))
+}
+
+// splitList splits a comma-separated value, ignoring empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getBool returns the environment variable as a boolean or fallback if it is
+// not set or invalid
+func getBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %t", key, value, fallback)
+		return fallback
+	}
+	return b
+}
+
+// getInt returns the environment variable as a non-negative integer or
+// fallback if it is not set or invalid
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+// getFloat returns the environment variable as a number between 0 and 1 or
+// fallback if it is not set or invalid
+func getFloat(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 0 || f > 1 {
+		log.Printf("Invalid %s %q, using %g", key, value, fallback)
+		return fallback
+	}
+	return f
+}
+
+// getDuration returns the environment variable as a duration (e.g. "1h") or
+// fallback if it is not set or invalid
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+// getAuthMode returns the environment variable as an auth mode or fallback
+// if it is not set or not a known mode
+func getAuthMode(key string, fallback AuthMode) AuthMode {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	mode := AuthMode(value)
+	if !mode.Valid() {
+		log.Printf("Invalid %s %q (expected firebase, jwt, dev-insecure or none), using %s", key, value, fallback)
+		return fallback
+	}
+	return mode
+}
+
+// getRateLimit returns the environment variable as a rate limit such as
+// "20/1m", a zero limit for "off", or fallback if it is not set or invalid
+func getRateLimit(key string, fallback RateLimit) RateLimit {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	if value == "off" {
+		return RateLimit{}
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if !ok || err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using %d/%s", key, value, fallback.Requests, fallback.Period)
+		return fallback
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %d/%s", key, value, fallback.Requests, fallback.Period)
+		return fallback
+	}
+	return RateLimit{Requests: n, Period: d}
+}
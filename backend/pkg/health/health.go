@@ -0,0 +1,173 @@
+// Package health runs the dependency checks that decide whether the server
+// is ready to take traffic.
+package health
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Check reports whether a dependency is usable, returning nil if it is
+type Check func(ctx context.Context) error
+
+// Checker is implemented by components that can check their own dependencies
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// Status is the health of a component or of the whole server
+type Status string
+
+const (
+	// StatusUp means every check passed
+	StatusUp Status = "up"
+
+	// StatusDegraded means only non-critical checks failed
+	StatusDegraded Status = "degraded"
+
+	// StatusDown means a critical check failed
+	StatusDown Status = "down"
+)
+
+// ComponentStatus is the outcome of the latest check of a component
+type ComponentStatus struct {
+	Name      string
+	Critical  bool
+	Status    Status
+	Latency   time.Duration
+	CheckedAt time.Time
+
+	// LastError and LastErrorAt describe the most recent failure, which may
+	// be older than the latest check. LastError is the raw error text and may
+	// name hosts or paths, so it is not meant for anonymous callers.
+	LastError   string
+	LastErrorAt time.Time
+}
+
+// Report is the outcome of running every registered check
+type Report struct {
+	Status     Status
+	Components []ComponentStatus
+}
+
+type component struct {
+	name     string
+	critical bool
+	check    Check
+
+	mu          sync.Mutex
+	failing     bool
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// Registry holds the checks contributed by infrastructure components
+type Registry struct {
+	timeout time.Duration
+
+	mu         sync.RWMutex
+	components []*component
+}
+
+// NewRegistry creates an empty registry whose checks each get at most timeout to complete
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds a check. The server is not ready while a critical check
+// fails; a failing non-critical check only degrades the report.
+func (r *Registry) Register(name string, critical bool, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components = append(r.components, &component{name: name, critical: critical, check: check})
+}
+
+// Run runs every check concurrently and reports their combined status
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	components := r.components
+	r.mu.RUnlock()
+
+	statuses := make([]ComponentStatus, len(components))
+	var wg sync.WaitGroup
+	for i, c := range components {
+		wg.Add(1)
+		go func(i int, c *component) {
+			defer wg.Done()
+			statuses[i] = c.run(ctx, r.timeout)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Components: statuses}
+	for _, s := range statuses {
+		if s.Status == StatusUp {
+			continue
+		}
+		if s.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// run runs the check once, recording the error if it fails and logging
+// when the component starts failing or recovers
+func (c *component) run(ctx context.Context, timeout time.Duration) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := safeCheck(ctx, c.check)
+	status := ComponentStatus{
+		Name:      c.name,
+		Critical:  c.critical,
+		Status:    StatusUp,
+		Latency:   time.Since(start),
+		CheckedAt: start,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		status.Status = StatusDown
+		c.lastError = err.Error()
+		c.lastErrorAt = start
+		if !c.failing {
+			log.Printf("Health check %s failed: %v", c.name, err)
+		}
+	} else if c.failing {
+		log.Printf("Health check %s recovered", c.name)
+	}
+	c.failing = err != nil
+	status.LastError = c.lastError
+	status.LastErrorAt = c.lastErrorAt
+	return status
+}
+
+// safeCheck runs check, giving up once ctx is done even if the check ignores
+// it, and turning a panic into an error so that one broken check cannot take
+// down the readiness endpoint
+func safeCheck(ctx context.Context, check Check) error {
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				result <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		result <- check(ctx)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func up(context.Context) error { return nil }
+
+func down(context.Context) error { return errors.New("connection refused") }
+
+func TestRegistryRun(t *testing.T) {
+	type check struct {
+		name     string
+		critical bool
+		check    Check
+	}
+
+	tests := []struct {
+		name   string
+		checks []check
+		want   Status
+	}{
+		{"no checks", nil, StatusUp},
+		{"all up", []check{{"storage", true, up}, {"mail", false, up}}, StatusUp},
+		{"non-critical down", []check{{"storage", true, up}, {"mail", false, down}}, StatusDegraded},
+		{"critical down", []check{{"storage", true, down}, {"mail", false, up}}, StatusDown},
+		{"critical and non-critical down", []check{{"mail", false, down}, {"storage", true, down}}, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(time.Second)
+			for _, c := range tt.checks {
+				r.Register(c.name, c.critical, c.check)
+			}
+
+			report := r.Run(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("Status = %q, want %q", report.Status, tt.want)
+			}
+			if len(report.Components) != len(tt.checks) {
+				t.Fatalf("got %d components, want %d", len(report.Components), len(tt.checks))
+			}
+			for i, c := range report.Components {
+				if c.Name != tt.checks[i].name || c.Critical != tt.checks[i].critical {
+					t.Errorf("component %d = %s (critical %t), want %s in registration order", i, c.Name, c.Critical, tt.checks[i].name)
+				}
+			}
+		})
+	}
+}
+
+// TestRegistryRunKeepsLastError checks that a component's last failure
+// outlives its recovery
+func TestRegistryRunKeepsLastError(t *testing.T) {
+	var err error
+	r := NewRegistry(time.Second)
+	r.Register("storage", true, func(context.Context) error { return err })
+
+	err = errors.New("connection refused")
+	failed := r.Run(context.Background()).Components[0]
+	if failed.Status != StatusDown || failed.LastError != "connection refused" || failed.LastErrorAt.IsZero() {
+		t.Fatalf("failed check = %+v, want down with its error", failed)
+	}
+
+	err = nil
+	recovered := r.Run(context.Background()).Components[0]
+	if recovered.Status != StatusUp {
+		t.Errorf("Status = %q after recovery, want up", recovered.Status)
+	}
+	if recovered.LastError != failed.LastError || !recovered.LastErrorAt.Equal(failed.LastErrorAt) {
+		t.Errorf("last error after recovery = %q at %v, want %q at %v",
+			recovered.LastError, recovered.LastErrorAt, failed.LastError, failed.LastErrorAt)
+	}
+}
+
+func TestSafeCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	tests := []struct {
+		name    string
+		check   Check
+		wantErr string
+	}{
+		{"passes", up, ""},
+		{"fails", down, "connection refused"},
+		{"panics", func(context.Context) error { panic("nil map") }, "check panicked: nil map"},
+		{"ignores its context", func(context.Context) error {
+			<-release
+			return nil
+		}, "check timed out: context deadline exceeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := safeCheck(ctx, tt.check)
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("safeCheck() took %v, want it bounded by the context", elapsed)
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("safeCheck() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("safeCheck() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRegistryRunTimeout checks that a hanging check marks only its own
+// component down, within the registry's timeout
+func TestRegistryRunTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	r := NewRegistry(50 * time.Millisecond)
+	r.Register("storage", true, up)
+	r.Register("mail", false, func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	report := r.Run(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() took %v, want it bounded by the check timeout", elapsed)
+	}
+
+	if report.Status != StatusDegraded {
+		t.Errorf("Status = %q, want degraded", report.Status)
+	}
+	if mail := report.Components[1]; mail.Status != StatusDown || !strings.Contains(mail.LastError, "timed out") {
+		t.Errorf("hanging check = %+v, want down with a timeout error", mail)
+	}
+}
@@ -0,0 +1,127 @@
+package ical
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// fold returns the physical lines writeLine produces for line
+func fold(line string) []string {
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	writeLine(w, line)
+	w.Flush()
+	return strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+}
+
+// unfold joins folded lines back into a content line, as RFC 5545 section
+// 3.1 describes
+func unfold(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			line = strings.TrimPrefix(line, " ")
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines int
+	}{
+		{"short", "SUMMARY:Drill", 1},
+		{"exactly the limit", strings.Repeat("a", maxLineOctets), 1},
+		{"one octet over", strings.Repeat("a", maxLineOctets+1), 2},
+		{"continuation limit", strings.Repeat("a", 2*maxLineOctets-1), 2},
+		{"continuation overflow", strings.Repeat("a", 2*maxLineOctets), 3},
+		{"multi-byte runes", "SUMMARY:" + strings.Repeat("é", 100), 3},
+		{"rune across the limit", strings.Repeat("a", maxLineOctets-1) + "€€", 2},
+		{"four-byte runes", strings.Repeat("🔨", 40), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := fold(tt.line)
+			if len(lines) != tt.lines {
+				t.Errorf("folded into %d lines, want %d: %q", len(lines), tt.lines, lines)
+			}
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d has %d octets, more than %d", i, len(line), maxLineOctets)
+				}
+				if i > 0 && !strings.HasPrefix(line, " ") {
+					t.Errorf("continuation line %d does not start with a space: %q", i, line)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+				}
+			}
+			if got := unfold(lines); got != tt.line {
+				t.Errorf("unfolded line = %q, want %q", got, tt.line)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Drill", "Drill"},
+		{"Drill, cordless; 18V", `Drill\, cordless\; 18V`},
+		{`C:\tools`, `C:\\tools`},
+		{"line one\nline two", `line one\nline two`},
+		{"line one\r\nline two", `line one\nline two`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarEncode(t *testing.T) {
+	start := time.Date(2030, time.January, 10, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	cal := &Calendar{
+		ProdID: "-//Tool Rental Club//EN",
+		Name:   "Drill",
+		Stamp:  time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Events: []Event{{
+			UID:     "r1@toolrentalclub",
+			Start:   start,
+			End:     start.Add(48 * time.Hour),
+			Summary: "Reserved",
+		}},
+	}
+
+	var b strings.Builder
+	if err := cal.Encode(&b); err != nil {
+		t.Fatalf("Encode() = %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Drill\r\n",
+		"DTSTAMP:20300101T000000Z\r\n",
+		"DTSTART:20300110T080000Z\r\n",
+		"DTEND:20300112T080000Z\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("calendar has a bare LF line ending")
+	}
+	if strings.Contains(out, "DESCRIPTION") {
+		t.Error("calendar has a DESCRIPTION for an event without one")
+	}
+}
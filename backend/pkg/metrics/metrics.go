@@ -0,0 +1,171 @@
+// Package metrics collects the Prometheus metrics exposed on /metrics.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "toolrentalclub"
+
+// overdueTimeout bounds the query that counts overdue tools on each scrape
+const overdueTimeout = 5 * time.Second
+
+// Metrics holds the application's collectors and the registry they are exposed from
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests      *prometheus.CounterVec
+	httpDuration      *prometheus.HistogramVec
+	authVerifications *prometheus.CounterVec
+	tokenCache        *prometheus.CounterVec
+	rateLimited       *prometheus.CounterVec
+	repoDuration      *prometheus.HistogramVec
+	rentalsCreated    prometheus.Counter
+}
+
+// New creates the application metrics along with the Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		authVerifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_verifications_total",
+			Help:      "Bearer token verifications on protected routes by result and failure reason.",
+		}, []string{"result", "reason"}),
+		tokenCache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_cache_lookups_total",
+			Help:      "Verified token cache lookups by result (hit or miss).",
+		}, []string{"result"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_rate_limited_total",
+			Help:      "Requests rejected for exceeding a rate limit, by route group.",
+		}, []string{"group"}),
+		repoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Latency of repository operations by repository and operation.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "operation"}),
+		rentalsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_created_total",
+			Help:      "Reservations created.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.authVerifications,
+		m.tokenCache,
+		m.rateLimited,
+		m.repoDuration,
+		m.rentalsCreated,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records a served request. route must be a route template
+// such as /api/tools/{id}, never a raw path, to keep label cardinality bounded.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// ObserveAuthVerification records the outcome of verifying a bearer token;
+// reason is empty on success
+func (m *Metrics) ObserveAuthVerification(success bool, reason string) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	m.authVerifications.WithLabelValues(result, reason).Inc()
+}
+
+// ObserveTokenCache records whether a verified token was found in the cache
+func (m *Metrics) ObserveTokenCache(hit bool) {
+	result := "hit"
+	if !hit {
+		result = "miss"
+	}
+	m.tokenCache.WithLabelValues(result).Inc()
+}
+
+// ObserveRateLimited counts a request rejected by the rate limit of a route group
+func (m *Metrics) ObserveRateLimited(group string) {
+	m.rateLimited.WithLabelValues(group).Inc()
+}
+
+// ObserveRepositoryOperation records the latency of a repository operation
+func (m *Metrics) ObserveRepositoryOperation(repository, operation string, d time.Duration) {
+	m.repoDuration.WithLabelValues(repository, operation).Observe(d.Seconds())
+}
+
+// ReservationCreated counts a newly created reservation
+func (m *Metrics) ReservationCreated() {
+	m.rentalsCreated.Inc()
+}
+
+// RegisterOverdueTools exposes a gauge of overdue tools, computed by count on every scrape
+func (m *Metrics) RegisterOverdueTools(count func(ctx context.Context) (int, error)) {
+	m.registry.MustRegister(&overdueCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "tools_overdue"),
+			"Tools picked up and not returned by the end of their rental.",
+			nil, nil,
+		),
+		count: count,
+	})
+}
+
+// overdueCollector queries the overdue count at scrape time so that the gauge
+// is never stale; a failed query leaves the gauge out of that scrape
+type overdueCollector struct {
+	desc  *prometheus.Desc
+	count func(ctx context.Context) (int, error)
+}
+
+func (c *overdueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *overdueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), overdueTimeout)
+	defer cancel()
+
+	n, err := c.count(ctx)
+	if err != nil {
+		slog.Error("failed to count overdue tools", "error", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(n))
+}
@@ -1,8 +1,17 @@
 package server
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -11,18 +20,139 @@ type Config struct {
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+
+	// ShutdownTimeout bounds how long in-flight requests are given to finish
+	// once a shutdown signal arrives, and separately how long shutdown hooks
+	// may run afterwards
+	ShutdownTimeout time.Duration
+
+	// ShutdownDelay is how long the server keeps serving after a shutdown
+	// signal while reporting not ready, so that load balancers stop routing
+	// to it before it stops accepting connections
+	ShutdownDelay time.Duration
+}
+
+// ShutdownHook releases a resource when the server stops
+type ShutdownHook func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	fn   ShutdownHook
 }
 
-// Start starts the HTTP server with the given configuration
-func Start(handler http.Handler, cfg Config) error {
+// Server runs the HTTP server until it receives SIGINT or SIGTERM, then
+// drains in-flight requests and runs the registered shutdown hooks
+type Server struct {
+	cfg   Config
+	ready atomic.Bool
+
+	mu    sync.Mutex
+	hooks []namedHook
+}
+
+// New creates a server with the given configuration
+func New(cfg Config) *Server {
+	return &Server{cfg: cfg}
+}
+
+// OnShutdown registers a hook to run after the server has stopped accepting
+// requests. Hooks run in reverse registration order, so resources opened
+// first are closed last.
+func (s *Server) OnShutdown(name string, fn ShutdownHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, namedHook{name: name, fn: fn})
+}
+
+// Ready reports whether the server is accepting traffic. It turns false as
+// soon as draining begins so that load balancers stop routing to it.
+func (s *Server) Ready() bool {
+	return s.ready.Load()
+}
+
+// Run serves handler until a shutdown signal arrives or the server fails.
+// A second signal during draining terminates the process immediately.
+func (s *Server) Run(handler http.Handler) error {
 	srv := &http.Server{
 		Handler:      handler,
-		Addr:         ":" + cfg.Port,
-		WriteTimeout: cfg.WriteTimeout,
-		ReadTimeout:  cfg.ReadTimeout,
+		Addr:         ":" + s.cfg.Port,
+		WriteTimeout: s.cfg.WriteTimeout,
+		ReadTimeout:  s.cfg.ReadTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to listen on port %s: %w", s.cfg.Port, err), s.runHooks())
 	}
 
-	log.Printf("Server starting on port %s", cfg.Port)
-	return srv.ListenAndServe()
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	log.Printf("Server starting on port %s", s.cfg.Port)
+	s.ready.Store(true)
+
+	select {
+	case err = <-serveErr:
+		s.ready.Store(false)
+		err = fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+		stop()
+		s.ready.Store(false)
+		err = s.drain(srv)
+	}
+
+	return errors.Join(err, s.runHooks())
 }
 
+// drain waits out the shutdown delay, then stops accepting connections and
+// waits for in-flight requests, closing the remaining connections once the
+// deadline passes
+func (s *Server) drain(srv *http.Server) error {
+	if s.cfg.ShutdownDelay > 0 {
+		log.Printf("Shutdown signal received; serving for %s more before draining", s.cfg.ShutdownDelay)
+		time.Sleep(s.cfg.ShutdownDelay)
+	}
+
+	log.Printf("Draining connections for up to %s", s.cfg.ShutdownTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		srv.Close()
+		return fmt.Errorf("failed to drain connections: %w", err)
+	}
+
+	log.Println("All connections drained")
+	return nil
+}
+
+// runHooks runs the shutdown hooks in reverse registration order, continuing
+// past failures so that every resource gets a chance to be released
+func (s *Server) runHooks() error {
+	s.mu.Lock()
+	hooks := s.hooks
+	s.hooks = nil
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if err := hook.fn(ctx); err != nil {
+			log.Printf("Shutdown hook %s failed: %v", hook.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		log.Printf("Shutdown hook %s completed", hook.name)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// freePort returns a TCP port that nothing listens on
+func freePort(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+}
+
+// waitFor polls cond until it holds or a few seconds have passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestServerRunHooks(t *testing.T) {
+	s := New(Config{ShutdownTimeout: time.Second})
+
+	var ran []string
+	for _, name := range []string{"database", "auth", "tracing"} {
+		name := name
+		s.OnShutdown(name, func(ctx context.Context) error {
+			ran = append(ran, name)
+			if name == "auth" {
+				return errors.New("still connected")
+			}
+			return nil
+		})
+	}
+
+	err := s.runHooks()
+	if want := []string{"tracing", "auth", "database"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("hooks ran in order %v, want %v", ran, want)
+	}
+	if err == nil || err.Error() != "auth: still connected" {
+		t.Errorf("runHooks() = %v, want the error of the auth hook", err)
+	}
+
+	ran = nil
+	if err := s.runHooks(); err != nil || len(ran) != 0 {
+		t.Errorf("second runHooks() = %v and ran %v, want the hooks to run once", err, ran)
+	}
+}
+
+// TestServerListenFailure checks that resources opened before the server
+// started are released when it cannot listen
+func TestServerListenFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	s := New(Config{Port: strconv.Itoa(ln.Addr().(*net.TCPAddr).Port), ShutdownTimeout: time.Second})
+	closed := false
+	s.OnShutdown("database", func(ctx context.Context) error {
+		closed = true
+		return nil
+	})
+
+	err = s.Run(http.NotFoundHandler())
+	if err == nil || !strings.Contains(err.Error(), "failed to listen") {
+		t.Errorf("Run() = %v, want a listen error", err)
+	}
+	if !closed {
+		t.Error("shutdown hook did not run after the listen failure")
+	}
+	if s.Ready() {
+		t.Error("Ready() = true after the listen failure")
+	}
+}
+
+// TestServerShutdown sends the process a SIGTERM and checks the server
+// reports not ready while it still serves during the shutdown delay, then
+// drains and runs the hooks
+func TestServerShutdown(t *testing.T) {
+	port := freePort(t)
+	s := New(Config{Port: port, ShutdownTimeout: 5 * time.Second, ShutdownDelay: 300 * time.Millisecond})
+
+	hookSawReady := true
+	s.OnShutdown("database", func(ctx context.Context) error {
+		hookSawReady = s.Ready()
+		return nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Run(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	}()
+	waitFor(t, "the server to become ready", s.Ready)
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := self.Signal(syscall.SIGTERM); err != nil {
+		t.Skipf("cannot signal the test process: %v", err)
+	}
+	waitFor(t, "the server to report not ready", func() bool { return !s.Ready() })
+
+	// Still inside the shutdown delay: requests are served while not ready
+	resp, err := http.Get("http://127.0.0.1:" + port + "/")
+	if err != nil {
+		t.Fatalf("request during the shutdown delay failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("request during the shutdown delay got %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after the shutdown signal")
+	}
+	if hookSawReady {
+		t.Error("shutdown hook ran while the server reported ready")
+	}
+	if _, err := http.Get("http://127.0.0.1:" + port + "/"); err == nil {
+		t.Error("server still accepts connections after Run() returned")
+	}
+}